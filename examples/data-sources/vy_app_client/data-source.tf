@@ -0,0 +1,9 @@
+# Look up an app client owned by another team, by its name
+data "vy_app_client" "trains" {
+  name = "trains-backend.vydev.io"
+}
+
+# Or by its client_id
+data "vy_app_client" "by_client_id" {
+  client_id = "1example23456789"
+}
@@ -0,0 +1,10 @@
+# List the backend app clients of a team that have access to a scope
+data "vy_app_clients" "trains_readers" {
+  name_prefix = "trains-"
+  type        = "backend"
+  scope       = "trains.vydev.io/read"
+}
+
+output "trains_reader_client_ids" {
+  value = [for client in data.vy_app_clients.trains_readers.app_clients : client.client_id]
+}
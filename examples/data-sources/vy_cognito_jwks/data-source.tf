@@ -0,0 +1,7 @@
+# Get the signing keys of the Cognito User Pool
+data "vy_cognito_jwks" "this" {}
+
+# E.g. to verify tokens with a key that is configured statically
+output "signing_keys" {
+  value = { for key in data.vy_cognito_jwks.this.keys : key.kid => key.pem }
+}
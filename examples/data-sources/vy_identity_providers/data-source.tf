@@ -0,0 +1,15 @@
+# Only let employees sign in to an internal tool, through the corporate SSO
+data "vy_identity_providers" "sso" {
+  type = "OIDC"
+}
+
+resource "vy_app_client" "internal_tool" {
+  name = "internal-tool.infrademo.vydev.io"
+  type = "frontend"
+
+  callback_urls = [
+    "https://internal-tool.infrademo.vydev.io/auth/callback",
+  ]
+
+  supported_identity_providers = data.vy_identity_providers.sso.names
+}
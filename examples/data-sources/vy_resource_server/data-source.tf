@@ -0,0 +1,13 @@
+# Look up a resource server owned by another team
+data "vy_resource_server" "trains" {
+  identifier = "trains.vydev.io"
+}
+
+resource "vy_app_client" "backend_application" {
+  name = "infrademo-backend.vydev.io"
+  type = "backend"
+
+  scopes = [
+    for scope in data.vy_resource_server.trains.scopes : "${data.vy_resource_server.trains.identifier}/${scope.name}"
+  ]
+}
@@ -0,0 +1,8 @@
+# List every resource server with a `read` scope
+data "vy_resource_servers" "readable" {
+  scope = "read"
+}
+
+output "readable_identifiers" {
+  value = [for server in data.vy_resource_servers.readable.resource_servers : server.identifier]
+}
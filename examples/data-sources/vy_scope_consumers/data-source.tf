@@ -0,0 +1,11 @@
+# Find out who still uses the scopes of our resource server
+data "vy_scope_consumers" "this" {
+  resource_server = "service.vydev.io"
+}
+
+output "read_consumers" {
+  value = [
+    for consumer in one([for scope in data.vy_scope_consumers.this.scopes : scope.consumers if scope.name == "read"]) :
+    "${consumer.name} (${consumer.account_id})"
+  ]
+}
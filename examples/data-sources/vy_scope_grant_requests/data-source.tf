@@ -0,0 +1,8 @@
+# List the app clients waiting for a scope of our resource server
+data "vy_scope_grant_requests" "pending" {
+  resource_server = "service.vydev.io"
+}
+
+output "pending_requests" {
+  value = [for request in data.vy_scope_grant_requests.pending.requests : "${request.app_client_name} wants ${request.scope}"]
+}
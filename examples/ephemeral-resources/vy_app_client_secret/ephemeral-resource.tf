@@ -0,0 +1,19 @@
+resource "vy_app_client" "backend_application" {
+  name = "infrademo-backend.vydev.io"
+  type = "backend"
+}
+
+# The secret is read when Terraform runs, and is never stored in the plan or state
+ephemeral "vy_app_client_secret" "backend_application" {
+  name = vy_app_client.backend_application.name
+}
+
+resource "aws_secretsmanager_secret" "client_secret" {
+  name = "infrademo-backend/client-secret"
+}
+
+resource "aws_secretsmanager_secret_version" "client_secret" {
+  secret_id                = aws_secretsmanager_secret.client_secret.id
+  secret_string_wo         = ephemeral.vy_app_client_secret.backend_application.client_secret
+  secret_string_wo_version = 1
+}
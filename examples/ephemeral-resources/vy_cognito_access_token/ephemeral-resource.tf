@@ -0,0 +1,28 @@
+resource "vy_app_client" "smoke_test" {
+  name = "infrademo-smoke-test.vydev.io"
+  type = "backend"
+
+  scopes = [
+    "https://infrademo.vydev.io/demo/read"
+  ]
+}
+
+ephemeral "vy_app_client_secret" "smoke_test" {
+  name = vy_app_client.smoke_test.name
+}
+
+# The token is requested when Terraform runs, and is never stored in the plan or state
+ephemeral "vy_cognito_access_token" "smoke_test" {
+  client_id     = ephemeral.vy_app_client_secret.smoke_test.client_id
+  client_secret = ephemeral.vy_app_client_secret.smoke_test.client_secret
+  scopes        = ["https://infrademo.vydev.io/demo/read"]
+}
+
+# Ephemeral values can be used in provider blocks, e.g. to configure an API protected by Cognito
+provider "restapi" {
+  uri = "https://infrademo.vydev.io/demo"
+
+  headers = {
+    Authorization = "Bearer ${ephemeral.vy_cognito_access_token.smoke_test.access_token}"
+  }
+}
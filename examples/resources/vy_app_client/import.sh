@@ -1,2 +1,5 @@
 # App clients can be imported using their name
-terraform import vy_app_client.backend_application "app_client_name"
+terraform import vy_app_client.backend_application "name:app_client_name"
+
+# Or using their client_id, which also imports app clients from the old delegated Cognito
+terraform import vy_app_client.backend_application "client_id:1example23456789"
@@ -0,0 +1,17 @@
+resource "vy_app_client" "backend_application" {
+  name = "infrademo-backend.vydev.io"
+  type = "backend"
+
+  scopes = [
+    "https://infrademo.vydev.io/demo/read"
+  ]
+
+  # Change the trigger to rotate the secret right away, e.g. after a leak.
+  secret_rotation_trigger = "2026-10-17"
+
+  # Rotate the secret on the first apply after it is 90 days old.
+  rotate_after_days = 90
+
+  # The previous secret keeps working for a day, so consumers can pick up the new one.
+  secret_rotation_overlap = "24h"
+}
@@ -0,0 +1,16 @@
+resource "vy_app_client" "internal_tool" {
+  name = "internal-tool.infrademo.vydev.io"
+  type = "frontend"
+
+  callback_urls = [
+    "https://internal-tool.infrademo.vydev.io/auth/callback",
+  ]
+  default_redirect_uri = "https://internal-tool.infrademo.vydev.io/auth/callback"
+
+  # Only allow the authorization code flow.
+  allowed_oauth_flows = ["code"]
+
+  access_token_validity_minutes = 15
+  id_token_validity_minutes     = 15
+  refresh_token_validity_days   = 1
+}
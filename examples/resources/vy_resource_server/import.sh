@@ -1,2 +1,3 @@
-# Resource servers can be imported using their identifier
+# Resource servers can be imported using their identifier.
+# Their scopes are not imported, so scopes managed by vy_resource_server_scope are kept.
 terraform import vy_resource_server.this "service.vydev.io"
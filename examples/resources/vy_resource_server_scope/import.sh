@@ -0,0 +1,2 @@
+# Resource server scopes can be imported using the identifier of their resource server and their name
+terraform import vy_resource_server_scope.write "service.vydev.io/write"
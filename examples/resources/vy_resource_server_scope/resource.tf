@@ -0,0 +1,5 @@
+resource "vy_resource_server_scope" "write" {
+  resource_server = "service.vydev.io"
+  name            = "write"
+  description     = "used for writing"
+}
@@ -0,0 +1,2 @@
+# Scope grants can be imported using their ID
+terraform import vy_scope_grant.infrademo "grant-1234"
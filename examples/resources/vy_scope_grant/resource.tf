@@ -0,0 +1,11 @@
+# Let every app client of the infrademo team request the read scope of our resource server
+resource "vy_scope_grant" "infrademo" {
+  scope                   = "service.vydev.io/read"
+  app_client_name_pattern = "*.infrademo.vydev.io"
+}
+
+# Or grant it to a single app client
+resource "vy_scope_grant" "reporting" {
+  scope           = "service.vydev.io/read"
+  app_client_name = "reporting.vydev.io"
+}
@@ -0,0 +1,97 @@
+package api_errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the kinds of failures our APIs can return.
+// Use errors.Is to check what kind of error a client call returned.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrThrottled    = errors.New("throttled")
+	ErrServerError  = errors.New("server error")
+)
+
+// Payload is the error body returned by our APIs.
+type Payload struct {
+	Message   string `json:"message"`
+	ErrorType string `json:"error_type"`
+}
+
+// Error is a non-successful response from one of our APIs.
+type Error struct {
+	// Operation describes what we tried to do, e.g. "could not read resource".
+	Operation  string
+	StatusCode int
+	// ErrorType is the `error_type` from the response payload, if any.
+	ErrorType string
+	// Message is the `message` from the response payload, or the raw body if it could not be parsed.
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Operation == "" {
+		return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("%s. %d: %s", e.Operation, e.StatusCode, e.Message)
+}
+
+// Is makes the error match the sentinel for its status code.
+func (e *Error) Is(target error) bool {
+	return kindOf(e.StatusCode) == target
+}
+
+func kindOf(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusTooManyRequests:
+		return ErrThrottled
+	case statusCode >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// FromResponse builds an *Error from a non-successful response.
+// It consumes the response body, but does not close it.
+func FromResponse(operation string, response *http.Response) error {
+	body, _ := io.ReadAll(response.Body)
+
+	apiErr := &Error{
+		Operation:  operation,
+		StatusCode: response.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err == nil && (payload.Message != "" || payload.ErrorType != "") {
+		apiErr.Message = payload.Message
+		apiErr.ErrorType = payload.ErrorType
+	}
+
+	return apiErr
+}
+
+// IsNotFound reports whether err is an API error for a resource that does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is an API error for a resource that already exists.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
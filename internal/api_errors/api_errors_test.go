@@ -0,0 +1,104 @@
+package api_errors
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func responseWith(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestFromResponse_ParsesApiErrorPayload(t *testing.T) {
+	err := FromResponse("could not read resource", responseWith(404, `{"message": "app client \"x\" not found", "error_type": "NOT_FOUND"}`))
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if apiErr.StatusCode != 404 {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, 404)
+	}
+	if apiErr.ErrorType != "NOT_FOUND" {
+		t.Errorf("ErrorType = %q, want %q", apiErr.ErrorType, "NOT_FOUND")
+	}
+	if err.Error() != `could not read resource. 404: app client "x" not found` {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestFromResponse_FallsBackToRawBody(t *testing.T) {
+	err := FromResponse("could not read resource", responseWith(502, "Bad Gateway\n"))
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if apiErr.Message != "Bad Gateway" {
+		t.Errorf("Message = %q, want %q", apiErr.Message, "Bad Gateway")
+	}
+	if apiErr.ErrorType != "" {
+		t.Errorf("ErrorType = %q, want it empty", apiErr.ErrorType)
+	}
+}
+
+func TestError_MatchesSentinelForStatusCode(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrThrottled},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusServiceUnavailable, ErrServerError},
+	}
+
+	sentinels := []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrThrottled, ErrServerError}
+
+	for _, tt := range tests {
+		err := FromResponse("", responseWith(tt.statusCode, ""))
+
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("errors.Is(%d, %v) = %v", tt.statusCode, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestError_BadRequestMatchesNoSentinel(t *testing.T) {
+	err := FromResponse("", responseWith(http.StatusBadRequest, ""))
+
+	for _, sentinel := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrThrottled, ErrServerError} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("expected 400 not to match %v", sentinel)
+		}
+	}
+}
+
+func TestIsNotFound_IsFalseForNilAndOtherErrors(t *testing.T) {
+	if IsNotFound(nil) {
+		t.Errorf("expected IsNotFound(nil) to be false")
+	}
+	if IsNotFound(errors.New("not found")) {
+		t.Errorf("expected plain errors not to be treated as not found")
+	}
+}
+
+func TestIsConflict_IsFalseForNilAndOtherErrors(t *testing.T) {
+	if IsConflict(nil) {
+		t.Errorf("expected IsConflict(nil) to be false")
+	}
+	if IsConflict(&Error{StatusCode: http.StatusNotFound}) {
+		t.Errorf("expected not found errors not to be treated as conflicts")
+	}
+}
@@ -5,35 +5,145 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
-// SignedRequest sends a request to our endpoint with AWS Signature V4.
+// credentialsExpiryWindow is how long before expiry cached credentials are refreshed,
+// so a request is never signed with credentials that are about to run out.
+const credentialsExpiryWindow = 5 * time.Minute
+
+const (
+	defaultRegion      = "eu-west-1"
+	defaultSessionName = "terraform-provider-vy"
+)
+
+// Signer signs requests to our endpoints with AWS Signature V4.
 // This is how we authenticate with the API.
-func SignedRequest(request *http.Request) (*http.Response, error) {
-	cfg, err := config.LoadDefaultConfig(context.Background())
-	if err != nil {
-		return nil, err
+//
+// A Signer is meant to be created once and shared by all clients,
+// so credentials are only resolved when they are about to expire.
+type Signer struct {
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	service     string
+}
+
+// NewSigner creates a signer using the given credentials.
+// The credentials are cached if they are not already.
+func NewSigner(credentials aws.CredentialsProvider) *Signer {
+	if !aws.IsCredentialsProvider(credentials, (*aws.CredentialsCache)(nil)) {
+		credentials = aws.NewCredentialsCache(credentials, func(options *aws.CredentialsCacheOptions) {
+			options.ExpiryWindow = credentialsExpiryWindow
+		})
+	}
+
+	return &Signer{
+		credentials: credentials,
+		signer:      v4.NewSigner(),
+		region:      defaultRegion,
+		service:     "execute-api",
+	}
+}
+
+// Config selects the AWS identity used to sign requests.
+// The zero value uses the default credential chain.
+type Config struct {
+	Profile           string
+	Region            string
+	SharedConfigFiles []string
+	AssumeRole        *AssumeRoleConfig
+}
+
+// AssumeRoleConfig is a role to assume on top of the base credentials.
+type AssumeRoleConfig struct {
+	RoleArn     string
+	SessionName string
+	ExternalId  string
+	Duration    time.Duration
+}
+
+// LoadSigner creates a signer from the AWS credential chain, as selected by the config.
+func LoadSigner(ctx context.Context, signerConfig Config) (*Signer, error) {
+	region := signerConfig.Region
+	if region == "" {
+		region = defaultRegion
+	}
+
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+		config.WithCredentialsCacheOptions(func(options *aws.CredentialsCacheOptions) {
+			options.ExpiryWindow = credentialsExpiryWindow
+		}),
+	}
+	if signerConfig.Profile != "" {
+		options = append(options, config.WithSharedConfigProfile(signerConfig.Profile))
+	}
+	if len(signerConfig.SharedConfigFiles) > 0 {
+		options = append(options, config.WithSharedConfigFiles(signerConfig.SharedConfigFiles))
 	}
 
-	credentials, err := cfg.Credentials.Retrieve(context.Background())
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
 	if err != nil {
 		return nil, err
 	}
 
-	signer := v4.NewSigner()
+	credentials := cfg.Credentials
+	if assumeRole := signerConfig.AssumeRole; assumeRole != nil {
+		credentials = stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), assumeRole.RoleArn, func(options *stscreds.AssumeRoleOptions) {
+			options.RoleSessionName = assumeRole.SessionName
+			if options.RoleSessionName == "" {
+				options.RoleSessionName = defaultSessionName
+			}
+			if assumeRole.ExternalId != "" {
+				options.ExternalID = aws.String(assumeRole.ExternalId)
+			}
+			if assumeRole.Duration > 0 {
+				options.Duration = assumeRole.Duration
+			}
+		})
+	}
+
+	signer := NewSigner(credentials)
+	signer.region = region
+
+	return signer, nil
+}
+
+// Retrieve resolves the credentials without signing a request.
+// Credentials are otherwise resolved on the first request, so this reports missing or invalid credentials early.
+// The credentials are cached for the requests that follow.
+func (s *Signer) Retrieve(ctx context.Context) error {
+	_, err := s.credentials.Retrieve(ctx)
+	return err
+}
+
+// Sign signs the request in place.
+func (s *Signer) Sign(request *http.Request) error {
+	if s == nil {
+		return errors.New("no AWS signer is configured for this client")
+	}
+
+	credentials, err := s.credentials.Retrieve(request.Context())
+	if err != nil {
+		return err
+	}
 
 	// Compute the SHA256 hash of the request body for signing
 	var payloadHash string
 	if request.Body != nil {
 		bodyBytes, err := io.ReadAll(request.Body)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		// Compute SHA256 hash
 		hash := sha256.Sum256(bodyBytes)
@@ -46,15 +156,5 @@ func SignedRequest(request *http.Request) (*http.Response, error) {
 		payloadHash = hex.EncodeToString(hash[:])
 	}
 
-	err = signer.SignHTTP(context.Background(), credentials, request, payloadHash, "execute-api", "eu-west-1", time.Now())
-	if err != nil {
-		return nil, err
-	}
-
-	response, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return nil, err
-	}
-
-	return response, nil
+	return s.signer.SignHTTP(request.Context(), credentials, request, payloadHash, s.service, s.region, time.Now())
 }
@@ -0,0 +1,177 @@
+package aws_auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type countingCredentials struct {
+	retrievals int
+	expires    time.Time
+}
+
+func (c *countingCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	c.retrievals++
+	return aws.Credentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		CanExpire:       !c.expires.IsZero(),
+		Expires:         c.expires,
+	}, nil
+}
+
+type failingCredentials struct{}
+
+func (failingCredentials) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	return aws.Credentials{}, errors.New("no credentials")
+}
+
+func TestRetrieve_CachesCredentialsForRequests(t *testing.T) {
+	credentials := &countingCredentials{expires: time.Now().Add(time.Hour)}
+	signer := NewSigner(credentials)
+
+	if err := signer.Retrieve(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://delegated.test.cognito.vydev.io/app-clients/x", nil)
+	if err := signer.Sign(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if credentials.retrievals != 1 {
+		t.Errorf("retrievals = %d, want %d", credentials.retrievals, 1)
+	}
+}
+
+func TestRetrieve_ReturnsErrorForMissingCredentials(t *testing.T) {
+	signer := NewSigner(failingCredentials{})
+
+	if err := signer.Retrieve(context.Background()); err == nil {
+		t.Fatal("expected an error for missing credentials, got nil")
+	}
+}
+
+func TestSign_ReusesCachedCredentialsAcrossRequests(t *testing.T) {
+	credentials := &countingCredentials{expires: time.Now().Add(time.Hour)}
+	signer := NewSigner(credentials)
+
+	for i := 0; i < 3; i++ {
+		request, _ := http.NewRequest(http.MethodGet, "https://delegated.test.cognito.vydev.io/app-clients/x", nil)
+		if err := signer.Sign(request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if credentials.retrievals != 1 {
+		t.Errorf("retrievals = %d, want %d", credentials.retrievals, 1)
+	}
+}
+
+func TestSign_RefreshesCredentialsBeforeTheyExpire(t *testing.T) {
+	credentials := &countingCredentials{expires: time.Now().Add(time.Minute)}
+	signer := NewSigner(credentials)
+
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest(http.MethodGet, "https://delegated.test.cognito.vydev.io/app-clients/x", nil)
+		if err := signer.Sign(request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if credentials.retrievals != 2 {
+		t.Errorf("retrievals = %d, want credentials within the expiry window to be refreshed", credentials.retrievals)
+	}
+}
+
+func TestSign_SetsSignatureAndKeepsBody(t *testing.T) {
+	signer := NewSigner(&countingCredentials{})
+
+	request, _ := http.NewRequest(http.MethodPost, "https://delegated.test.cognito.vydev.io/app-clients", bytes.NewBufferString(`{"name":"my-app"}`))
+	if err := signer.Sign(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	authorization := request.Header.Get("Authorization")
+	if !strings.Contains(authorization, "AKIDEXAMPLE/") || !strings.Contains(authorization, "/eu-west-1/execute-api/") {
+		t.Errorf("Authorization = %q, want a SigV4 signature for execute-api in eu-west-1", authorization)
+	}
+
+	body, _ := io.ReadAll(request.Body)
+	if string(body) != `{"name":"my-app"}` {
+		t.Errorf("body = %q, want it to be restored after signing", body)
+	}
+}
+
+func TestSign_ReturnsErrorWithoutSigner(t *testing.T) {
+	var signer *Signer
+
+	request, _ := http.NewRequest(http.MethodGet, "https://delegated.test.cognito.vydev.io/app-clients/x", nil)
+	if err := signer.Sign(request); err == nil {
+		t.Fatal("expected an error when no signer is configured, got nil")
+	}
+}
+
+func TestLoadSigner_UsesProfileAndRegionFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	err := os.WriteFile(configFile, []byte(`
+[profile deployment]
+aws_access_key_id = AKIDPROFILE
+aws_secret_access_key = secret
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	signer, err := LoadSigner(context.Background(), Config{
+		Profile:           "deployment",
+		Region:            "eu-north-1",
+		SharedConfigFiles: []string{configFile},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://enroll.vydev.io/accounts", nil)
+	if err := signer.Sign(request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	authorization := request.Header.Get("Authorization")
+	if !strings.Contains(authorization, "AKIDPROFILE/") || !strings.Contains(authorization, "/eu-north-1/execute-api/") {
+		t.Errorf("Authorization = %q, want it signed by the profile in eu-north-1", authorization)
+	}
+}
+
+func TestLoadSigner_ReturnsErrorForUnknownProfile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	if err := os.WriteFile(configFile, []byte("[default]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+
+	_, err := LoadSigner(context.Background(), Config{
+		Profile:           "does-not-exist",
+		SharedConfigFiles: []string{configFile},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unknown profile, got nil")
+	}
+}
@@ -2,14 +2,13 @@ package central_cognito
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 
-	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 )
 
 type AppClient struct {
@@ -21,6 +20,21 @@ type AppClient struct {
 	LogoutUrls     []string `json:"logout_urls"`
 	ClientId       *string  `json:"client_id"`
 	ClientSecret   *string  `json:"client_secret"`
+	// ClientSecretCreatedAt is when the current secret was created, in RFC 3339 format.
+	ClientSecretCreatedAt *string `json:"client_secret_created_at"`
+	// AccountId is the AWS account that owns the app client. It is decided by the server from the caller.
+	AccountId string `json:"account_id,omitempty"`
+
+	// The settings below are derived from the type by the server when they are not set.
+	// The lists are pointers, so an empty list is sent as `[]` instead of being left out.
+	AccessTokenValidityMinutes *int64    `json:"access_token_validity_minutes,omitempty"`
+	IdTokenValidityMinutes     *int64    `json:"id_token_validity_minutes,omitempty"`
+	RefreshTokenValidityDays   *int64    `json:"refresh_token_validity_days,omitempty"`
+	AllowedOAuthFlows          *[]string `json:"allowed_oauth_flows,omitempty"`
+	PreventUserExistenceErrors *bool     `json:"prevent_user_existence_errors,omitempty"`
+	EnableTokenRevocation      *bool     `json:"enable_token_revocation,omitempty"`
+	DefaultRedirectUri         *string   `json:"default_redirect_uri,omitempty"`
+	SupportedIdentityProviders *[]string `json:"supported_identity_providers,omitempty"`
 }
 
 type AppClientUpdateRequest struct {
@@ -28,15 +42,26 @@ type AppClientUpdateRequest struct {
 	Scopes       []string `json:"scopes"`
 	CallbackUrls []string `json:"callback_urls"`
 	LogoutUrls   []string `json:"logout_urls"`
+
+	// Settings that are left out keep their current value. An empty list is sent, and clears the list.
+	AccessTokenValidityMinutes *int64    `json:"access_token_validity_minutes,omitempty"`
+	IdTokenValidityMinutes     *int64    `json:"id_token_validity_minutes,omitempty"`
+	RefreshTokenValidityDays   *int64    `json:"refresh_token_validity_days,omitempty"`
+	AllowedOAuthFlows          *[]string `json:"allowed_oauth_flows,omitempty"`
+	PreventUserExistenceErrors *bool     `json:"prevent_user_existence_errors,omitempty"`
+	EnableTokenRevocation      *bool     `json:"enable_token_revocation,omitempty"`
+	DefaultRedirectUri         *string   `json:"default_redirect_uri,omitempty"`
+	SupportedIdentityProviders *[]string `json:"supported_identity_providers,omitempty"`
 }
 
-func (c Client) ReadAppClient(name string, server *AppClient) error {
+func (c Client) ReadAppClient(ctx context.Context, name string, server *AppClient) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
 		fmt.Sprintf("%s%s/app-clients/%s", protocol, c.BaseUrl, url.QueryEscape(name)),
 		nil,
@@ -45,12 +70,7 @@ func (c Client) ReadAppClient(name string, server *AppClient) error {
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -58,10 +78,44 @@ func (c Client) ReadAppClient(name string, server *AppClient) error {
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
+		return api_errors.FromResponse("could not read resource", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(server)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReadAppClientByClientId reads the app client with the given client_id.
+// Unlike the name, the client_id never changes, so it is used to keep track of the app client.
+func (c Client) ReadAppClientByClientId(ctx context.Context, clientId string, server *AppClient) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
 
-		return errors.New(fmt.Sprintf("could not read resource. %s", str))
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s%s/app-clients/by-client-id/%s", protocol, c.BaseUrl, url.QueryEscape(clientId)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
 
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read resource", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(server)
@@ -72,7 +126,12 @@ func (c Client) ReadAppClient(name string, server *AppClient) error {
 	return nil
 }
 
-func (c Client) CreateAppClient(server AppClient) (*AppClient, error) {
+// ListAppClients reads all app clients, following the pagination of the API.
+func (c Client) ListAppClients(ctx context.Context) ([]AppClient, error) {
+	return listAll[AppClient](ctx, c, "app-clients")
+}
+
+func (c Client) CreateAppClient(ctx context.Context, server AppClient) (*AppClient, error) {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -85,7 +144,8 @@ func (c Client) CreateAppClient(server AppClient) (*AppClient, error) {
 		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		fmt.Sprintf("%s%s/app-clients", protocol, c.BaseUrl),
 		&data,
@@ -94,22 +154,15 @@ func (c Client) CreateAppClient(server AppClient) (*AppClient, error) {
 		return nil, err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return nil, err
 	}
 
-	if response.StatusCode != 201 {
-		defer response.Body.Close()
-
-		str, _ := io.ReadAll(response.Body)
+	defer response.Body.Close()
 
-		return nil, errors.New(fmt.Sprintf("could not create resource. %s", str))
+	if response.StatusCode != 201 {
+		return nil, api_errors.FromResponse("could not create resource", response)
 	}
 
 	var createdAppClient AppClient
@@ -121,7 +174,7 @@ func (c Client) CreateAppClient(server AppClient) (*AppClient, error) {
 	return &createdAppClient, nil
 }
 
-func (c Client) UpdateAppClient(updateRequest AppClientUpdateRequest) error {
+func (c Client) UpdateAppClient(ctx context.Context, updateRequest AppClientUpdateRequest) (*AppClient, error) {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -131,45 +184,47 @@ func (c Client) UpdateAppClient(updateRequest AppClientUpdateRequest) error {
 
 	err := json.NewEncoder(&data).Encode(updateRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPut,
 		fmt.Sprintf("%s%s/app-clients/%s", protocol, c.BaseUrl, url.QueryEscape(updateRequest.Name)),
 		&data,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if response.StatusCode != 200 {
-		defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
+	defer response.Body.Close()
 
-		return errors.New(fmt.Sprintf("could not update resource. %s", str))
+	if response.StatusCode != 200 {
+		return nil, api_errors.FromResponse("could not update resource", response)
 	}
 
-	return nil
+	var updatedAppClient AppClient
+	err = json.NewDecoder(response.Body).Decode(&updatedAppClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedAppClient, nil
 }
 
-func (c Client) DeleteAppClient(name string) error {
+func (c Client) DeleteAppClient(ctx context.Context, name string) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodDelete,
 		fmt.Sprintf("%s%s/app-clients/%s", protocol, c.BaseUrl, url.QueryEscape(name)),
 		nil,
@@ -178,12 +233,7 @@ func (c Client) DeleteAppClient(name string) error {
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -191,9 +241,7 @@ func (c Client) DeleteAppClient(name string) error {
 	if response.StatusCode != 200 {
 		defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not delete resource. %s", str))
+		return api_errors.FromResponse("could not delete resource", response)
 	}
 
 	return nil
@@ -203,7 +251,7 @@ type ImportAppClientRequest struct {
 	ClientId string `json:"client_id"`
 }
 
-func (c Client) ImportAppClient(client_id string, server *AppClient) error {
+func (c Client) ImportAppClient(ctx context.Context, client_id string, server *AppClient) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -216,7 +264,8 @@ func (c Client) ImportAppClient(client_id string, server *AppClient) error {
 		return err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		fmt.Sprintf("%s%s/import/app-client", protocol, c.BaseUrl),
 		&data,
@@ -225,12 +274,7 @@ func (c Client) ImportAppClient(client_id string, server *AppClient) error {
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -238,9 +282,7 @@ func (c Client) ImportAppClient(client_id string, server *AppClient) error {
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not import resource. %s", str))
+		return api_errors.FromResponse("could not import resource", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(server)
@@ -250,3 +292,108 @@ func (c Client) ImportAppClient(client_id string, server *AppClient) error {
 
 	return nil
 }
+
+type RotateAppClientSecretRequest struct {
+	// OverlapSeconds is how long the previous secret stays valid after the new one is created.
+	OverlapSeconds int64 `json:"overlap_seconds"`
+}
+
+type AppClientSecretRotation struct {
+	ClientSecret            string `json:"client_secret"`
+	ClientSecretCreatedAt   string `json:"client_secret_created_at"`
+	PreviousSecretExpiresAt string `json:"previous_secret_expires_at"`
+}
+
+// RotateAppClientSecret creates a new secret for the app client.
+// Both the new and the previous secret are valid until the overlap has passed, so consumers can switch without downtime.
+func (c Client) RotateAppClientSecret(ctx context.Context, name string, rotateRequest RotateAppClientSecretRequest) (*AppClientSecretRotation, error) {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	var data bytes.Buffer
+
+	err := json.NewEncoder(&data).Encode(rotateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s%s/app-clients/%s/rotate-secret", protocol, c.BaseUrl, url.QueryEscape(name)),
+		&data,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, api_errors.FromResponse("could not rotate secret", response)
+	}
+
+	var rotation AppClientSecretRotation
+	err = json.NewDecoder(response.Body).Decode(&rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rotation, nil
+}
+
+type RenameAppClientRequest struct {
+	NewName string `json:"new_name"`
+}
+
+// RenameAppClient changes the name of the app client, which is its key in the API.
+// The client keeps its client_id and secret, so consumers are not affected.
+func (c Client) RenameAppClient(ctx context.Context, name string, newName string) (*AppClient, error) {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	var data bytes.Buffer
+
+	err := json.NewEncoder(&data).Encode(RenameAppClientRequest{NewName: newName})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s%s/app-clients/%s/rename", protocol, c.BaseUrl, url.QueryEscape(name)),
+		&data,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, api_errors.FromResponse("could not rename resource", response)
+	}
+
+	var renamed AppClient
+	err = json.NewDecoder(response.Body).Decode(&renamed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &renamed, nil
+}
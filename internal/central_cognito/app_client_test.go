@@ -1,19 +1,25 @@
-package central_cognito
+package central_cognito_test
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
 )
 
 func TestCreateAppClient_ReturnsCreatedClientWithGeneratedId(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	result, err := client.CreateAppClient(AppClient{
+	result, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
 		Name:   "my-app",
 		Scopes: []string{"read", "write"},
 	})
@@ -38,15 +44,15 @@ func TestCreateAppClient_ReturnsCreatedClientWithGeneratedId(t *testing.T) {
 }
 
 func TestCreateAppClient_ReturnsClientSecretWhenGenerateSecretIsTrue(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
 	genSecret := true
-	result, err := client.CreateAppClient(AppClient{
+	result, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
 		Name:           "secret-app",
 		Scopes:         []string{"admin"},
 		GenerateSecret: &genSecret,
@@ -62,23 +68,51 @@ func TestCreateAppClient_ReturnsClientSecretWhenGenerateSecretIsTrue(t *testing.
 	}
 }
 
+func TestCreateAppClient_DerivesUnsetSettingsFromType(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	accessTokenValidity := int64(15)
+	result, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
+		Name:                       "backend-app",
+		Type:                       "backend",
+		AccessTokenValidityMinutes: &accessTokenValidity,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.AccessTokenValidityMinutes == nil || *result.AccessTokenValidityMinutes != 15 {
+		t.Errorf("expected AccessTokenValidityMinutes 15, got %v", result.AccessTokenValidityMinutes)
+	}
+	if result.RefreshTokenValidityDays == nil || *result.RefreshTokenValidityDays != 30 {
+		t.Errorf("expected default RefreshTokenValidityDays 30, got %v", result.RefreshTokenValidityDays)
+	}
+	if result.AllowedOAuthFlows == nil || len(*result.AllowedOAuthFlows) != 1 || (*result.AllowedOAuthFlows)[0] != "client_credentials" {
+		t.Errorf("expected AllowedOAuthFlows [client_credentials], got %v", result.AllowedOAuthFlows)
+	}
+}
+
 func TestReadAppClient_ReturnsAppClientForMatchingName(t *testing.T) {
 	clientID := "existing-client-id"
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"existing-app": {
 				Name:     "existing-app",
 				Scopes:   []string{"read"},
 				ClientId: &clientID,
 			},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result AppClient
-	err := client.ReadAppClient("existing-app", &result)
+	var result central_cognito.AppClient
+	err := client.ReadAppClient(context.Background(), "existing-app", &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -92,20 +126,20 @@ func TestReadAppClient_ReturnsAppClientForMatchingName(t *testing.T) {
 
 func TestUpdateAppClient_UpdatesScopesForExistingClient(t *testing.T) {
 	clientID := "cid"
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"my-app": {
 				Name:     "my-app",
 				Scopes:   []string{"read"},
 				ClientId: &clientID,
 			},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.UpdateAppClient(AppClientUpdateRequest{
+	_, err := client.UpdateAppClient(context.Background(), central_cognito.AppClientUpdateRequest{
 		Name:   "my-app",
 		Scopes: []string{"read", "write"},
 	})
@@ -113,8 +147,8 @@ func TestUpdateAppClient_UpdatesScopesForExistingClient(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	var result AppClient
-	err = client.ReadAppClient("my-app", &result)
+	var result central_cognito.AppClient
+	err = client.ReadAppClient(context.Background(), "my-app", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading after update: %v", err)
 	}
@@ -123,27 +157,111 @@ func TestUpdateAppClient_UpdatesScopesForExistingClient(t *testing.T) {
 	}
 }
 
+func TestUpdateAppClient_KeepsSettingsThatAreLeftOut(t *testing.T) {
+	clientID := "cid"
+	idTokenValidity := int64(60)
+	revocation := true
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"my-app": {
+				Name:                   "my-app",
+				ClientId:               &clientID,
+				IdTokenValidityMinutes: &idTokenValidity,
+				EnableTokenRevocation:  &revocation,
+				AllowedOAuthFlows:      &[]string{"code"},
+			},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	redirectUri := "https://example.com/callback"
+	updated, err := client.UpdateAppClient(context.Background(), central_cognito.AppClientUpdateRequest{
+		Name:               "my-app",
+		AllowedOAuthFlows:  &[]string{"code", "implicit"},
+		DefaultRedirectUri: &redirectUri,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.IdTokenValidityMinutes == nil || *updated.IdTokenValidityMinutes != 60 {
+		t.Errorf("expected the stored app client to be returned, got IdTokenValidityMinutes %v", updated.IdTokenValidityMinutes)
+	}
+
+	var result central_cognito.AppClient
+	err = client.ReadAppClient(context.Background(), "my-app", &result)
+	if err != nil {
+		t.Fatalf("unexpected error reading after update: %v", err)
+	}
+	if result.AllowedOAuthFlows == nil || len(*result.AllowedOAuthFlows) != 2 {
+		t.Errorf("expected 2 OAuth flows after update, got %v", result.AllowedOAuthFlows)
+	}
+	if result.DefaultRedirectUri == nil || *result.DefaultRedirectUri != redirectUri {
+		t.Errorf("expected DefaultRedirectUri %q, got %v", redirectUri, result.DefaultRedirectUri)
+	}
+	if result.IdTokenValidityMinutes == nil || *result.IdTokenValidityMinutes != 60 {
+		t.Errorf("expected IdTokenValidityMinutes to stay 60, got %v", result.IdTokenValidityMinutes)
+	}
+	if result.EnableTokenRevocation == nil || !*result.EnableTokenRevocation {
+		t.Errorf("expected EnableTokenRevocation to stay true, got %v", result.EnableTokenRevocation)
+	}
+}
+
+func TestUpdateAppClient_ClearsListsThatAreEmpty(t *testing.T) {
+	clientID := "cid"
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"my-app": {
+				Name:                       "my-app",
+				Type:                       "frontend",
+				ClientId:                   &clientID,
+				AllowedOAuthFlows:          &[]string{"code"},
+				SupportedIdentityProviders: &[]string{"COGNITO"},
+			},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	updated, err := client.UpdateAppClient(context.Background(), central_cognito.AppClientUpdateRequest{
+		Name:                       "my-app",
+		AllowedOAuthFlows:          &[]string{},
+		SupportedIdentityProviders: &[]string{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.AllowedOAuthFlows == nil || len(*updated.AllowedOAuthFlows) != 0 {
+		t.Errorf("expected the OAuth flows to be cleared, got %v", updated.AllowedOAuthFlows)
+	}
+	if updated.SupportedIdentityProviders == nil || len(*updated.SupportedIdentityProviders) != 0 {
+		t.Errorf("expected the identity providers to be cleared, got %v", updated.SupportedIdentityProviders)
+	}
+}
+
 func TestDeleteAppClient_RemovesClientSoReadReturnsError(t *testing.T) {
 	clientID := "cid"
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"to-delete": {
 				Name:     "to-delete",
 				ClientId: &clientID,
 			},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.DeleteAppClient("to-delete")
+	err := client.DeleteAppClient(context.Background(), "to-delete")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	var result AppClient
-	err = client.ReadAppClient("to-delete", &result)
+	var result central_cognito.AppClient
+	err = client.ReadAppClient(context.Background(), "to-delete", &result)
 	if err == nil {
 		t.Fatalf("expected error reading deleted app client, got nil")
 	}
@@ -151,21 +269,21 @@ func TestDeleteAppClient_RemovesClientSoReadReturnsError(t *testing.T) {
 
 func TestImportAppClient_ReturnsAppClientForMatchingClientId(t *testing.T) {
 	clientID := "import-client-id"
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"importable-app": {
 				Name:     "importable-app",
 				Scopes:   []string{"admin"},
 				ClientId: &clientID,
 			},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result AppClient
-	err := client.ImportAppClient("import-client-id", &result)
+	var result central_cognito.AppClient
+	err := client.ImportAppClient(context.Background(), "import-client-id", &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -175,15 +293,15 @@ func TestImportAppClient_ReturnsAppClientForMatchingClientId(t *testing.T) {
 }
 
 func TestReadAppClient_ReturnsErrorWhenClientDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result AppClient
-	err := client.ReadAppClient("nonexistent", &result)
+	var result central_cognito.AppClient
+	err := client.ReadAppClient(context.Background(), "nonexistent", &result)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -193,14 +311,14 @@ func TestReadAppClient_ReturnsErrorWhenClientDoesNotExist(t *testing.T) {
 }
 
 func TestDeleteAppClient_ReturnsErrorWhenClientDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.DeleteAppClient("nonexistent")
+	err := client.DeleteAppClient(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -210,15 +328,15 @@ func TestDeleteAppClient_ReturnsErrorWhenClientDoesNotExist(t *testing.T) {
 }
 
 func TestImportAppClient_ReturnsErrorWhenClientIdDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result AppClient
-	err := client.ImportAppClient("no-such-client-id", &result)
+	var result central_cognito.AppClient
+	err := client.ImportAppClient(context.Background(), "no-such-client-id", &result)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -228,16 +346,16 @@ func TestImportAppClient_ReturnsErrorWhenClientIdDoesNotExist(t *testing.T) {
 }
 
 func TestCreateAppClient_ReturnsErrorWhenClientAlreadyExists(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"existing": {Name: "existing"},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	_, err := client.CreateAppClient(AppClient{Name: "existing"})
+	_, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{Name: "existing"})
 	if err == nil {
 		t.Fatalf("expected conflict error, got nil")
 	}
@@ -247,14 +365,14 @@ func TestCreateAppClient_ReturnsErrorWhenClientAlreadyExists(t *testing.T) {
 }
 
 func TestCreateAppClient_AcceptsEmptyScopes(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	result, err := client.CreateAppClient(AppClient{
+	result, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
 		Name:   "no-scopes-app",
 		Scopes: []string{},
 	})
@@ -268,21 +386,21 @@ func TestCreateAppClient_AcceptsEmptyScopes(t *testing.T) {
 
 func TestReadAppClient_HandlesUrlEncodedName(t *testing.T) {
 	clientID := "cid"
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
 			"app with spaces": {
 				Name:     "app with spaces",
 				Scopes:   []string{"read"},
 				ClientId: &clientID,
 			},
 		},
-		ResourceServers: map[string]ResourceServer{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result AppClient
-	err := client.ReadAppClient("app with spaces", &result)
+	var result central_cognito.AppClient
+	err := client.ReadAppClient(context.Background(), "app with spaces", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading URL-encoded name: %v", err)
 	}
@@ -290,3 +408,265 @@ func TestReadAppClient_HandlesUrlEncodedName(t *testing.T) {
 		t.Errorf("expected Name %q, got %q", "app with spaces", result.Name)
 	}
 }
+
+func TestReadAppClient_ReturnsNotFoundErrorWhenClientDoesNotExist(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var result central_cognito.AppClient
+	err := client.ReadAppClient(context.Background(), "nonexistent", &result)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+
+	var apiErr *api_errors.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *api_errors.Error, got %T", err)
+	}
+	if apiErr.ErrorType != "NOT_FOUND" {
+		t.Errorf("expected ErrorType %q, got %q", "NOT_FOUND", apiErr.ErrorType)
+	}
+}
+
+func TestCreateAppClient_ReturnsConflictErrorWhenClientAlreadyExists(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"existing": {Name: "existing"},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{Name: "existing"})
+	if !errors.Is(err, api_errors.ErrConflict) {
+		t.Fatalf("expected a conflict error, got: %v", err)
+	}
+}
+
+func TestRotateAppClientSecret_ReplacesSecretAndKeepsClientId(t *testing.T) {
+	clientID := "cid"
+	secret := "old-secret"
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"backend-app": {
+				Name:         "backend-app",
+				ClientId:     &clientID,
+				ClientSecret: &secret,
+			},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	rotation, err := client.RotateAppClientSecret(context.Background(), "backend-app", central_cognito.RotateAppClientSecretRequest{
+		OverlapSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotation.ClientSecret == "" || rotation.ClientSecret == secret {
+		t.Errorf("expected a new secret, got %q", rotation.ClientSecret)
+	}
+	if rotation.ClientSecretCreatedAt == "" || rotation.PreviousSecretExpiresAt == "" {
+		t.Errorf("expected creation and expiry times, got %+v", rotation)
+	}
+
+	var result central_cognito.AppClient
+	err = client.ReadAppClient(context.Background(), "backend-app", &result)
+	if err != nil {
+		t.Fatalf("unexpected error reading after rotation: %v", err)
+	}
+	if *result.ClientSecret != rotation.ClientSecret {
+		t.Errorf("expected the rotated secret %q, got %q", rotation.ClientSecret, *result.ClientSecret)
+	}
+	if *result.ClientId != clientID {
+		t.Errorf("expected client_id %q to be kept, got %q", clientID, *result.ClientId)
+	}
+}
+
+func TestRotateAppClientSecret_ReturnsNotFoundErrorWhenClientDoesNotExist(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.RotateAppClientSecret(context.Background(), "nonexistent", central_cognito.RotateAppClientSecretRequest{})
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestRotateAppClientSecret_ReturnsErrorWhenClientHasNoSecret(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"frontend-app": {Name: "frontend-app"},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.RotateAppClientSecret(context.Background(), "frontend-app", central_cognito.RotateAppClientSecretRequest{})
+	if err == nil {
+		t.Fatalf("expected error rotating a client without a secret, got nil")
+	}
+}
+
+func TestRenameAppClient_MovesClientAndKeepsClientId(t *testing.T) {
+	clientID := "cid"
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"old-name": {
+				Name:     "old-name",
+				ClientId: &clientID,
+			},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	renamed, err := client.RenameAppClient(context.Background(), "old-name", "new-name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if renamed.Name != "new-name" || *renamed.ClientId != clientID {
+		t.Errorf("expected new-name with client_id %q, got %s with %q", clientID, renamed.Name, *renamed.ClientId)
+	}
+
+	var result central_cognito.AppClient
+	if err := client.ReadAppClient(context.Background(), "new-name", &result); err != nil {
+		t.Fatalf("unexpected error reading renamed client: %v", err)
+	}
+	if err := client.ReadAppClient(context.Background(), "old-name", &result); !api_errors.IsNotFound(err) {
+		t.Errorf("expected the old name to be gone, got: %v", err)
+	}
+}
+
+func TestRenameAppClient_ReturnsConflictErrorWhenNewNameIsTaken(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"old-name": {Name: "old-name"},
+			"taken":    {Name: "taken"},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.RenameAppClient(context.Background(), "old-name", "taken")
+	if !errors.Is(err, api_errors.ErrConflict) {
+		t.Fatalf("expected a conflict error, got: %v", err)
+	}
+}
+
+func TestRenameAppClient_ReturnsNotFoundErrorWhenClientDoesNotExist(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.RenameAppClient(context.Background(), "nonexistent", "new-name")
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestReadAppClientByClientId_FollowsRenamedClient(t *testing.T) {
+	clientID := "stable-client-id"
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"old-name": {
+				Name:     "old-name",
+				ClientId: &clientID,
+			},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	if _, err := client.RenameAppClient(context.Background(), "old-name", "new-name"); err != nil {
+		t.Fatalf("unexpected error renaming: %v", err)
+	}
+
+	var result central_cognito.AppClient
+	err := client.ReadAppClientByClientId(context.Background(), clientID, &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "new-name" {
+		t.Errorf("expected name %q, got %q", "new-name", result.Name)
+	}
+}
+
+func TestReadAppClientByClientId_ReturnsNotFoundErrorWhenClientIdDoesNotExist(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var result central_cognito.AppClient
+	err := client.ReadAppClientByClientId(context.Background(), "nonexistent", &result)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestListAppClients_FollowsPagesUntilAllClientsAreRead(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"a": {Name: "a"},
+			"b": {Name: "b"},
+			"c": {Name: "c"},
+			"d": {Name: "d"},
+			"e": {Name: "e"},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+		PageSize:        2,
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	result, err := client.ListAppClients(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, appClient := range result {
+		names = append(names, appClient.Name)
+	}
+	if strings.Join(names, ",") != "a,b,c,d,e" {
+		t.Errorf("expected all app clients across pages, got %v", names)
+	}
+}
+
+func TestListAppClients_ReturnsEmptyListWhenThereAreNoClients(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	result, err := client.ListAppClients(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result == nil || len(result) != 0 {
+		t.Errorf("expected an empty list, got %v", result)
+	}
+}
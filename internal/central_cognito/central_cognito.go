@@ -1,8 +1,103 @@
 package central_cognito
 
-import "net/http"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
+)
 
 type Client struct {
-	BaseUrl    string
-	HTTPClient *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	BaseUrl        string
+	HTTPClient     *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	Signer         *aws_auth.Signer
+	Retry          transport.RetryConfig
+	RequestTimeout time.Duration
+}
+
+// logSubsystem is the tflog subsystem that requests to this service are logged to.
+const logSubsystem = "central_cognito"
+
+func (c Client) send(request *http.Request) (*http.Response, error) {
+	t := transport.Transport{
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Timeout:    c.RequestTimeout,
+		Subsystem:  logSubsystem,
+	}
+	if c.HTTPClient == nil {
+		t.Sign = c.Signer.Sign
+	}
+
+	return t.Do(request)
+}
+
+// sendUnsigned sends a request to the public endpoints of the user pool, which don't accept our signatures.
+func (c Client) sendUnsigned(request *http.Request) (*http.Response, error) {
+	return transport.Transport{
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Timeout:    c.RequestTimeout,
+		Subsystem:  logSubsystem,
+	}.Do(request)
+}
+
+// page is one page of a listed collection. NextToken is empty on the last page.
+type page[T any] struct {
+	Items     []T    `json:"items"`
+	NextToken string `json:"next_token"`
+}
+
+// listAll reads every page of a collection, like `app-clients`, by following the next_token of each page.
+func listAll[T any](ctx context.Context, c Client, collection string) ([]T, error) {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	items := []T{}
+	nextToken := ""
+
+	for {
+		listUrl := fmt.Sprintf("%s%s/%s", protocol, c.BaseUrl, collection)
+		if nextToken != "" {
+			listUrl += "?" + url.Values{"next_token": {nextToken}}.Encode()
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, listUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := c.send(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != 200 {
+			defer response.Body.Close()
+
+			return nil, api_errors.FromResponse("could not list resources", response)
+		}
+
+		var current page[T]
+		err = json.NewDecoder(response.Body).Decode(&current)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, current.Items...)
+
+		if current.NextToken == "" {
+			return items, nil
+		}
+		nextToken = current.NextToken
+	}
 }
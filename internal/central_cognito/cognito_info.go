@@ -0,0 +1,99 @@
+package central_cognito
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+// CognitoInfo describes the user pool that delegated Cognito manages in an environment.
+type CognitoInfo struct {
+	UserPoolId       string   `json:"user_pool_id"`
+	Region           string   `json:"region"`
+	AuthUrl          string   `json:"auth_url"`
+	Issuer           string   `json:"issuer"`
+	JwksUrl          string   `json:"jwks_uri"`
+	OpenIdUrl        string   `json:"openid_configuration_uri"`
+	TokenEndpoint    string   `json:"token_endpoint"`
+	UserinfoEndpoint string   `json:"userinfo_endpoint"`
+	LogoutEndpoint   string   `json:"logout_endpoint"`
+	ScopesSupported  []string `json:"scopes_supported"`
+}
+
+// ReadCognitoInfo reads the discovery document of the user pool.
+func (c Client) ReadCognitoInfo(ctx context.Context, info *CognitoInfo) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s%s/discovery", protocol, c.BaseUrl),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read discovery document", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(info)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OpenIdConfiguration is the OpenID Connect discovery document that the user pool publishes itself.
+type OpenIdConfiguration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	// EndSessionEndpoint is left out by user pools without a logout endpoint.
+	EndSessionEndpoint string   `json:"end_session_endpoint,omitempty"`
+	JwksUri            string   `json:"jwks_uri"`
+	ScopesSupported    []string `json:"scopes_supported"`
+}
+
+// ReadOpenIdConfiguration reads the /.well-known/openid-configuration of the user pool.
+// It is public, so it is read without signing the request.
+func (c Client) ReadOpenIdConfiguration(ctx context.Context, openIdUrl string, config *OpenIdConfiguration) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, openIdUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.sendUnsigned(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read OpenID configuration", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(config)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package central_cognito_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func TestReadCognitoInfo_ReturnsDiscoveryDocument(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		Info: &central_cognito.CognitoInfo{
+			UserPoolId:      "eu-west-1_example",
+			Region:          "eu-west-1",
+			TokenEndpoint:   "https://auth.example.com/oauth2/token",
+			ScopesSupported: []string{"openid", "email"},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var info central_cognito.CognitoInfo
+	err := client.ReadCognitoInfo(context.Background(), &info)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.UserPoolId != "eu-west-1_example" || info.Region != "eu-west-1" {
+		t.Errorf("expected the user pool and region, got %+v", info)
+	}
+	if info.TokenEndpoint != "https://auth.example.com/oauth2/token" || len(info.ScopesSupported) != 2 {
+		t.Errorf("expected the token endpoint and scopes, got %+v", info)
+	}
+}
+
+func TestReadCognitoInfo_ReturnsNotFoundErrorWithoutUserPool(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{}
+	server, client := api.Start()
+	defer server.Close()
+
+	var info central_cognito.CognitoInfo
+	err := client.ReadCognitoInfo(context.Background(), &info)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestReadOpenIdConfiguration_ReturnsConfigurationOfUserPool(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		Info: &central_cognito.CognitoInfo{
+			Issuer:          "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_example",
+			JwksUrl:         "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_example/.well-known/jwks.json",
+			TokenEndpoint:   "https://auth.example.com/oauth2/token",
+			ScopesSupported: []string{"openid", "email"},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var config central_cognito.OpenIdConfiguration
+	err := client.ReadOpenIdConfiguration(context.Background(), server.URL+"/.well-known/openid-configuration", &config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Issuer != api.Info.Issuer || config.JwksUri != api.Info.JwksUrl {
+		t.Errorf("expected the issuer and jwks_uri, got %+v", config)
+	}
+	if config.TokenEndpoint != "https://auth.example.com/oauth2/token" || len(config.ScopesSupported) != 2 {
+		t.Errorf("expected the token endpoint and scopes, got %+v", config)
+	}
+}
+
+func TestReadOpenIdConfiguration_ReturnsNotFoundErrorWithoutUserPool(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{}
+	server, client := api.Start()
+	defer server.Close()
+
+	var config central_cognito.OpenIdConfiguration
+	err := client.ReadOpenIdConfiguration(context.Background(), server.URL+"/.well-known/openid-configuration", &config)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
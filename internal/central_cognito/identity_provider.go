@@ -0,0 +1,16 @@
+package central_cognito
+
+import "context"
+
+// IdentityProvider is an identity provider registered in the central user pool.
+// The built-in user directory of the pool is listed as `COGNITO`.
+type IdentityProvider struct {
+	Name string `json:"name"`
+	// Type is the kind of provider, e.g. `OIDC`, `SAML` or `COGNITO`.
+	Type string `json:"type"`
+}
+
+// ListIdentityProviders reads all identity providers, following the pagination of the API.
+func (c Client) ListIdentityProviders(ctx context.Context) ([]IdentityProvider, error) {
+	return listAll[IdentityProvider](ctx, c, "identity-providers")
+}
@@ -0,0 +1,66 @@
+package central_cognito_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func TestListIdentityProviders_FollowsPagesUntilAllProvidersAreRead(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+		IdentityProviders: map[string]central_cognito.IdentityProvider{
+			"COGNITO": {Name: "COGNITO", Type: "COGNITO"},
+			"EntraID": {Name: "EntraID", Type: "OIDC"},
+		},
+		PageSize: 1,
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	result, err := client.ListIdentityProviders(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected 2 identity providers across pages, got %v", result)
+	}
+	if !slices.Contains(result, central_cognito.IdentityProvider{Name: "EntraID", Type: "OIDC"}) {
+		t.Errorf("expected EntraID to be listed as OIDC, got %v", result)
+	}
+}
+
+func TestCreateAppClient_RejectsUnknownIdentityProvider(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+		IdentityProviders: map[string]central_cognito.IdentityProvider{
+			"COGNITO": {Name: "COGNITO", Type: "COGNITO"},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
+		Name:                       "sso-only",
+		Type:                       "frontend",
+		SupportedIdentityProviders: &[]string{"EntraID"},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for an unknown identity provider")
+	}
+	var apiErr *api_errors.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Fatalf("expected a 400 *api_errors.Error, got %v", err)
+	}
+	if !strings.Contains(apiErr.Message, "EntraID") {
+		t.Errorf("expected the message to name the identity provider, got %q", apiErr.Message)
+	}
+}
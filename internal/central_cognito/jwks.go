@@ -0,0 +1,88 @@
+package central_cognito
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+// JsonWebKey is a public key that the user pool signs tokens with, as described in RFC 7517.
+type JsonWebKey struct {
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	// N and E are the modulus and exponent of an RSA key, base64url encoded.
+	N string `json:"n"`
+	E string `json:"e"`
+}
+
+type JsonWebKeySet struct {
+	Keys []JsonWebKey `json:"keys"`
+}
+
+// ReadJwks reads the signing keys of the user pool from its jwks_uri.
+func (c Client) ReadJwks(ctx context.Context, jwksUrl string, jwks *JsonWebKeySet) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.sendUnsigned(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read signing keys", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(jwks)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PublicKeyPEM encodes the key as a PEM "PUBLIC KEY" block, for tools that can't read JWKs.
+// Only RSA keys are supported, which is what Cognito signs with.
+func (k JsonWebKey) PublicKeyPEM() (string, error) {
+	if k.Kty != "RSA" {
+		return "", fmt.Errorf("key %s has type %q, only RSA keys are supported", k.Kid, k.Kty)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return "", fmt.Errorf("the modulus of key %s is not base64url encoded: %w", k.Kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return "", fmt.Errorf("the exponent of key %s is not base64url encoded: %w", k.Kid, err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return "", fmt.Errorf("the exponent of key %s is too large", k.Kid)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -0,0 +1,88 @@
+package central_cognito_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func rsaJsonWebKey(t *testing.T, kid string) (central_cognito.JsonWebKey, *rsa.PublicKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return central_cognito.JsonWebKey{
+		Kid: kid,
+		Alg: "RS256",
+		Kty: "RSA",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}, &key.PublicKey
+}
+
+func TestReadJwks_ReturnsKeysOfTheUserPool(t *testing.T) {
+	jwk, _ := rsaJsonWebKey(t, "key-1")
+	api := &fakes.CentralCognitoAPI{
+		Jwks: &central_cognito.JsonWebKeySet{Keys: []central_cognito.JsonWebKey{jwk}},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var jwks central_cognito.JsonWebKeySet
+	err := client.ReadJwks(context.Background(), server.URL+"/.well-known/jwks.json", &jwks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(jwks.Keys) != 1 || jwks.Keys[0] != jwk {
+		t.Errorf("expected the key of the fake, got %+v", jwks.Keys)
+	}
+}
+
+func TestPublicKeyPEM_EncodesTheRSAKey(t *testing.T) {
+	jwk, publicKey := rsaJsonWebKey(t, "key-1")
+
+	encoded, err := jwk.PublicKeyPEM()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		t.Fatalf("expected a PUBLIC KEY block, got %q", encoded)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("could not parse the encoded key: %v", err)
+	}
+	if !publicKey.Equal(parsed) {
+		t.Errorf("the encoded key is not the original key")
+	}
+}
+
+func TestPublicKeyPEM_ReturnsErrorForUnsupportedKeys(t *testing.T) {
+	tests := map[string]central_cognito.JsonWebKey{
+		"elliptic curve key": {Kid: "ec", Kty: "EC"},
+		"invalid modulus":    {Kid: "rsa", Kty: "RSA", N: "not base64!", E: "AQAB"},
+		"invalid exponent":   {Kid: "rsa", Kty: "RSA", N: "AQAB", E: "not base64!"},
+	}
+
+	for name, jwk := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := jwk.PublicKeyPEM(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
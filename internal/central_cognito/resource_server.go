@@ -2,14 +2,13 @@ package central_cognito
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 
-	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 )
 
 type Scope struct {
@@ -24,18 +23,21 @@ type ResourceServer struct {
 }
 
 type ResourceServerUpdateRequest struct {
-	Identifier string  `json:"identifier"`
-	Name       string  `json:"name"`
-	Scopes     []Scope `json:"scopes"`
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+	// Scopes replace all the scopes of the resource server. When left out, the scopes are kept as they are,
+	// so scopes added one by one through the scope endpoints are not lost.
+	Scopes []Scope `json:"scopes,omitempty"`
 }
 
-func (c Client) ReadResourceServer(identifier string, server *ResourceServer) error {
+func (c Client) ReadResourceServer(ctx context.Context, identifier string, server *ResourceServer) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
 		fmt.Sprintf("%s%s/resource-servers/%s", protocol, c.BaseUrl, url.QueryEscape(identifier)),
 		nil,
@@ -44,12 +46,7 @@ func (c Client) ReadResourceServer(identifier string, server *ResourceServer) er
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -57,10 +54,7 @@ func (c Client) ReadResourceServer(identifier string, server *ResourceServer) er
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not read resource. %s", str))
-
+		return api_errors.FromResponse("could not read resource", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(server)
@@ -71,7 +65,12 @@ func (c Client) ReadResourceServer(identifier string, server *ResourceServer) er
 	return nil
 }
 
-func (c Client) CreateResourceServer(server ResourceServer) error {
+// ListResourceServers reads all resource servers, following the pagination of the API.
+func (c Client) ListResourceServers(ctx context.Context) ([]ResourceServer, error) {
+	return listAll[ResourceServer](ctx, c, "resource-servers")
+}
+
+func (c Client) CreateResourceServer(ctx context.Context, server ResourceServer) (*ResourceServer, error) {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -81,40 +80,40 @@ func (c Client) CreateResourceServer(server ResourceServer) error {
 
 	err := json.NewEncoder(&data).Encode(server)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		fmt.Sprintf("%s%s/resource-servers", protocol, c.BaseUrl),
 		&data,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if response.StatusCode != 201 {
-		defer response.Body.Close()
+	defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
+	if response.StatusCode != 201 {
+		return nil, api_errors.FromResponse("could not create resource", response)
+	}
 
-		return errors.New(fmt.Sprintf("could not create resource. %s", str))
+	var createdResourceServer ResourceServer
+	err = json.NewDecoder(response.Body).Decode(&createdResourceServer)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &createdResourceServer, nil
 }
 
-func (c Client) UpdateResourceServer(updateRequest ResourceServerUpdateRequest) error {
+func (c Client) UpdateResourceServer(ctx context.Context, updateRequest ResourceServerUpdateRequest) (*ResourceServer, error) {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -124,45 +123,47 @@ func (c Client) UpdateResourceServer(updateRequest ResourceServerUpdateRequest)
 
 	err := json.NewEncoder(&data).Encode(updateRequest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPut,
 		fmt.Sprintf("%s%s/resource-servers/%s", protocol, c.BaseUrl, url.QueryEscape(updateRequest.Identifier)),
 		&data,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if response.StatusCode != 200 {
-		defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
+	defer response.Body.Close()
 
-		return errors.New(fmt.Sprintf("could not update resource. %s", str))
+	if response.StatusCode != 200 {
+		return nil, api_errors.FromResponse("could not update resource", response)
 	}
 
-	return nil
+	var updatedResourceServer ResourceServer
+	err = json.NewDecoder(response.Body).Decode(&updatedResourceServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedResourceServer, nil
 }
 
-func (c Client) DeleteResourceServer(identifier string) error {
+func (c Client) DeleteResourceServer(ctx context.Context, identifier string) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodDelete,
 		fmt.Sprintf("%s%s/resource-servers/%s", protocol, c.BaseUrl, url.QueryEscape(identifier)),
 		nil,
@@ -171,12 +172,7 @@ func (c Client) DeleteResourceServer(identifier string) error {
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -184,9 +180,7 @@ func (c Client) DeleteResourceServer(identifier string) error {
 	if response.StatusCode != 200 {
 		defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not delete resource. %s", str))
+		return api_errors.FromResponse("could not delete resource", response)
 	}
 
 	return nil
@@ -196,7 +190,7 @@ type ImportResourceServerRequest struct {
 	Identifier string `json:"identifier"`
 }
 
-func (c Client) ImportResourceServer(identifier string, server *ResourceServer) error {
+func (c Client) ImportResourceServer(ctx context.Context, identifier string, server *ResourceServer) error {
 	protocol := "https://"
 	if c.HTTPClient != nil {
 		protocol = "http://"
@@ -209,7 +203,8 @@ func (c Client) ImportResourceServer(identifier string, server *ResourceServer)
 		return err
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
 		fmt.Sprintf("%s%s/import/resource-server", protocol, c.BaseUrl),
 		&data,
@@ -218,12 +213,7 @@ func (c Client) ImportResourceServer(identifier string, server *ResourceServer)
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -231,10 +221,7 @@ func (c Client) ImportResourceServer(identifier string, server *ResourceServer)
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not import resource. %s", str))
-
+		return api_errors.FromResponse("could not import resource", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(server)
@@ -0,0 +1,152 @@
+package central_cognito
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+// CreateResourceServerScope adds a single scope to a resource server, leaving its other scopes as they are.
+func (c Client) CreateResourceServerScope(ctx context.Context, identifier string, scope Scope) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	var data bytes.Buffer
+
+	err := json.NewEncoder(&data).Encode(scope)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s%s/resource-servers/%s/scopes", protocol, c.BaseUrl, url.QueryEscape(identifier)),
+		&data,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 201 {
+		defer response.Body.Close()
+
+		return api_errors.FromResponse("could not create resource", response)
+	}
+
+	return nil
+}
+
+func (c Client) ReadResourceServerScope(ctx context.Context, identifier string, name string, scope *Scope) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s%s/resource-servers/%s/scopes/%s", protocol, c.BaseUrl, url.QueryEscape(identifier), url.QueryEscape(name)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read resource", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(scope)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateResourceServerScope changes the description of the scope with the same name.
+func (c Client) UpdateResourceServerScope(ctx context.Context, identifier string, scope Scope) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	var data bytes.Buffer
+
+	err := json.NewEncoder(&data).Encode(scope)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("%s%s/resource-servers/%s/scopes/%s", protocol, c.BaseUrl, url.QueryEscape(identifier), url.QueryEscape(scope.Name)),
+		&data,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != 200 {
+		defer response.Body.Close()
+
+		return api_errors.FromResponse("could not update resource", response)
+	}
+
+	return nil
+}
+
+func (c Client) DeleteResourceServerScope(ctx context.Context, identifier string, name string) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s%s/resource-servers/%s/scopes/%s", protocol, c.BaseUrl, url.QueryEscape(identifier), url.QueryEscape(name)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 {
+		defer response.Body.Close()
+
+		return api_errors.FromResponse("could not delete resource", response)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package central_cognito_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func TestCreateResourceServerScope_AddsScopeAndKeepsTheOthers(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Name:       "Example API",
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read access"}},
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	err := client.CreateResourceServerScope(context.Background(), "https://api.example.com", central_cognito.Scope{Name: "write", Description: "Write access"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
+	if err != nil {
+		t.Fatalf("unexpected error reading after create: %v", err)
+	}
+	if len(result.Scopes) != 2 {
+		t.Errorf("expected 2 scopes, got %d", len(result.Scopes))
+	}
+}
+
+func TestCreateResourceServerScope_ReturnsConflictForExistingScope(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read access"}},
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	err := client.CreateResourceServerScope(context.Background(), "https://api.example.com", central_cognito.Scope{Name: "read", Description: "Again"})
+	if !errors.Is(err, api_errors.ErrConflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+}
+
+func TestUpdateResourceServerScope_ChangesDescription(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read access"}},
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	err := client.UpdateResourceServerScope(context.Background(), "https://api.example.com", central_cognito.Scope{Name: "read", Description: "Read everything"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result central_cognito.Scope
+	err = client.ReadResourceServerScope(context.Background(), "https://api.example.com", "read", &result)
+	if err != nil {
+		t.Fatalf("unexpected error reading after update: %v", err)
+	}
+	if result.Description != "Read everything" {
+		t.Errorf("expected Description %q, got %q", "Read everything", result.Description)
+	}
+}
+
+func TestDeleteResourceServerScope_RemovesOnlyThatScope(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Scopes: []central_cognito.Scope{
+					{Name: "read", Description: "Read access"},
+					{Name: "write", Description: "Write access"},
+				},
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	err := client.DeleteResourceServerScope(context.Background(), "https://api.example.com", "write")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result central_cognito.Scope
+	err = client.ReadResourceServerScope(context.Background(), "https://api.example.com", "write", &result)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected the deleted scope to be not found, got %v", err)
+	}
+	err = client.ReadResourceServerScope(context.Background(), "https://api.example.com", "read", &result)
+	if err != nil {
+		t.Fatalf("expected the other scope to be kept, got %v", err)
+	}
+}
+
+func TestUpdateResourceServer_KeepsScopesWhenLeftOut(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Name:       "Old Name",
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read"}},
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.UpdateResourceServer(context.Background(), central_cognito.ResourceServerUpdateRequest{
+		Identifier: "https://api.example.com",
+		Name:       "New Name",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
+	if err != nil {
+		t.Fatalf("unexpected error reading after update: %v", err)
+	}
+	if len(result.Scopes) != 1 {
+		t.Errorf("expected the scope to be kept, got %d scopes", len(result.Scopes))
+	}
+}
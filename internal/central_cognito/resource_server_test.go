@@ -1,31 +1,39 @@
-package central_cognito
+package central_cognito_test
 
 import (
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
 )
 
 func TestCreateResourceServer_CreatesServerAndReadReturnsIt(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.CreateResourceServer(ResourceServer{
+	created, err := client.CreateResourceServer(context.Background(), central_cognito.ResourceServer{
 		Identifier: "https://api.example.com",
 		Name:       "Example API",
-		Scopes: []Scope{
+		Scopes: []central_cognito.Scope{
 			{Name: "read", Description: "Read access"},
 		},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if created.Identifier != "https://api.example.com" || len(created.Scopes) != 1 {
+		t.Errorf("expected the created resource server to be returned, got %+v", created)
+	}
 
-	var result ResourceServer
-	err = client.ReadResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading after create: %v", err)
 	}
@@ -38,13 +46,13 @@ func TestCreateResourceServer_CreatesServerAndReadReturnsIt(t *testing.T) {
 }
 
 func TestReadResourceServer_ReturnsServerForMatchingIdentifier(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com": {
 				Identifier: "https://api.example.com",
 				Name:       "Example API",
-				Scopes: []Scope{
+				Scopes: []central_cognito.Scope{
 					{Name: "read", Description: "Read access"},
 					{Name: "write", Description: "Write access"},
 				},
@@ -54,8 +62,8 @@ func TestReadResourceServer_ReturnsServerForMatchingIdentifier(t *testing.T) {
 	server, client := api.Start()
 	defer server.Close()
 
-	var result ResourceServer
-	err := client.ReadResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err := client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -68,23 +76,23 @@ func TestReadResourceServer_ReturnsServerForMatchingIdentifier(t *testing.T) {
 }
 
 func TestUpdateResourceServer_UpdatesNameAndScopesForExistingServer(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com": {
 				Identifier: "https://api.example.com",
 				Name:       "Old Name",
-				Scopes:     []Scope{{Name: "read", Description: "Read"}},
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read"}},
 			},
 		},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.UpdateResourceServer(ResourceServerUpdateRequest{
+	updated, err := client.UpdateResourceServer(context.Background(), central_cognito.ResourceServerUpdateRequest{
 		Identifier: "https://api.example.com",
 		Name:       "New Name",
-		Scopes: []Scope{
+		Scopes: []central_cognito.Scope{
 			{Name: "read", Description: "Read"},
 			{Name: "write", Description: "Write"},
 		},
@@ -92,9 +100,12 @@ func TestUpdateResourceServer_UpdatesNameAndScopesForExistingServer(t *testing.T
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if updated.Name != "New Name" || len(updated.Scopes) != 2 {
+		t.Errorf("expected the updated resource server to be returned, got %+v", updated)
+	}
 
-	var result ResourceServer
-	err = client.ReadResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading after update: %v", err)
 	}
@@ -107,9 +118,9 @@ func TestUpdateResourceServer_UpdatesNameAndScopesForExistingServer(t *testing.T
 }
 
 func TestDeleteResourceServer_RemovesServerSoReadReturnsError(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com": {
 				Identifier: "https://api.example.com",
 				Name:       "Example API",
@@ -119,34 +130,34 @@ func TestDeleteResourceServer_RemovesServerSoReadReturnsError(t *testing.T) {
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.DeleteResourceServer("https://api.example.com")
+	err := client.DeleteResourceServer(context.Background(), "https://api.example.com")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	var result ResourceServer
-	err = client.ReadResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
 	if err == nil {
 		t.Fatalf("expected error reading deleted resource server, got nil")
 	}
 }
 
 func TestImportResourceServer_ReturnsServerForMatchingIdentifier(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com": {
 				Identifier: "https://api.example.com",
 				Name:       "Example API",
-				Scopes:     []Scope{{Name: "admin", Description: "Admin access"}},
+				Scopes:     []central_cognito.Scope{{Name: "admin", Description: "Admin access"}},
 			},
 		},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result ResourceServer
-	err := client.ImportResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err := client.ImportResourceServer(context.Background(), "https://api.example.com", &result)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -159,15 +170,15 @@ func TestImportResourceServer_ReturnsServerForMatchingIdentifier(t *testing.T) {
 }
 
 func TestReadResourceServer_ReturnsErrorWhenServerDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result ResourceServer
-	err := client.ReadResourceServer("nonexistent", &result)
+	var result central_cognito.ResourceServer
+	err := client.ReadResourceServer(context.Background(), "nonexistent", &result)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -177,14 +188,14 @@ func TestReadResourceServer_ReturnsErrorWhenServerDoesNotExist(t *testing.T) {
 }
 
 func TestDeleteResourceServer_ReturnsErrorWhenServerDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.DeleteResourceServer("nonexistent")
+	err := client.DeleteResourceServer(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -194,15 +205,15 @@ func TestDeleteResourceServer_ReturnsErrorWhenServerDoesNotExist(t *testing.T) {
 }
 
 func TestImportResourceServer_ReturnsErrorWhenIdentifierDoesNotExist(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	var result ResourceServer
-	err := client.ImportResourceServer("nonexistent", &result)
+	var result central_cognito.ResourceServer
+	err := client.ImportResourceServer(context.Background(), "nonexistent", &result)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -212,9 +223,9 @@ func TestImportResourceServer_ReturnsErrorWhenIdentifierDoesNotExist(t *testing.
 }
 
 func TestCreateResourceServer_ReturnsErrorWhenServerAlreadyExists(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com": {
 				Identifier: "https://api.example.com",
 				Name:       "Existing",
@@ -224,7 +235,7 @@ func TestCreateResourceServer_ReturnsErrorWhenServerAlreadyExists(t *testing.T)
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.CreateResourceServer(ResourceServer{
+	_, err := client.CreateResourceServer(context.Background(), central_cognito.ResourceServer{
 		Identifier: "https://api.example.com",
 		Name:       "Duplicate",
 	})
@@ -237,24 +248,24 @@ func TestCreateResourceServer_ReturnsErrorWhenServerAlreadyExists(t *testing.T)
 }
 
 func TestCreateResourceServer_AcceptsEmptyScopes(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients:      map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{},
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
 	}
 	server, client := api.Start()
 	defer server.Close()
 
-	err := client.CreateResourceServer(ResourceServer{
+	_, err := client.CreateResourceServer(context.Background(), central_cognito.ResourceServer{
 		Identifier: "https://api.example.com",
 		Name:       "No Scopes API",
-		Scopes:     []Scope{},
+		Scopes:     []central_cognito.Scope{},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	var result ResourceServer
-	err = client.ReadResourceServer("https://api.example.com", &result)
+	var result central_cognito.ResourceServer
+	err = client.ReadResourceServer(context.Background(), "https://api.example.com", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading: %v", err)
 	}
@@ -264,9 +275,9 @@ func TestCreateResourceServer_AcceptsEmptyScopes(t *testing.T) {
 }
 
 func TestReadResourceServer_HandlesUrlEncodedIdentifier(t *testing.T) {
-	api := &FakeCentralCognitoAPI{
-		AppClients: map[string]AppClient{},
-		ResourceServers: map[string]ResourceServer{
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
 			"https://api.example.com/my resource": {
 				Identifier: "https://api.example.com/my resource",
 				Name:       "Spaced API",
@@ -276,8 +287,8 @@ func TestReadResourceServer_HandlesUrlEncodedIdentifier(t *testing.T) {
 	server, client := api.Start()
 	defer server.Close()
 
-	var result ResourceServer
-	err := client.ReadResourceServer("https://api.example.com/my resource", &result)
+	var result central_cognito.ResourceServer
+	err := client.ReadResourceServer(context.Background(), "https://api.example.com/my resource", &result)
 	if err != nil {
 		t.Fatalf("unexpected error reading URL-encoded identifier: %v", err)
 	}
@@ -285,3 +296,40 @@ func TestReadResourceServer_HandlesUrlEncodedIdentifier(t *testing.T) {
 		t.Errorf("expected Identifier %q, got %q", "https://api.example.com/my resource", result.Identifier)
 	}
 }
+
+func TestReadResourceServer_ReturnsNotFoundErrorWhenServerDoesNotExist(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	var result central_cognito.ResourceServer
+	err := client.ReadResourceServer(context.Background(), "nonexistent", &result)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestListResourceServers_FollowsPagesUntilAllServersAreRead(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"one.example.com":   {Identifier: "one.example.com"},
+			"two.example.com":   {Identifier: "two.example.com"},
+			"three.example.com": {Identifier: "three.example.com"},
+		},
+		PageSize: 1,
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	result, err := client.ListResourceServers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result) != 3 {
+		t.Errorf("expected 3 resource servers across pages, got %v", result)
+	}
+}
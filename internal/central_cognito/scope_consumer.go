@@ -0,0 +1,21 @@
+package central_cognito
+
+import (
+	"context"
+	"net/url"
+)
+
+// ScopeConsumer is an app client that holds a scope of a resource server, whichever team it belongs to.
+type ScopeConsumer struct {
+	// Scope is the name of the scope within the resource server, like `read`.
+	Scope         string `json:"scope"`
+	AppClientName string `json:"app_client_name"`
+	AppClientType string `json:"app_client_type"`
+	// AccountId is the AWS account that owns the app client.
+	AccountId string `json:"account_id"`
+}
+
+// ListScopeConsumers reads every app client holding a scope of the resource server, following the pagination of the API.
+func (c Client) ListScopeConsumers(ctx context.Context, identifier string) ([]ScopeConsumer, error) {
+	return listAll[ScopeConsumer](ctx, c, "resource-servers/"+url.QueryEscape(identifier)+"/consumers")
+}
@@ -0,0 +1,62 @@
+package central_cognito_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func TestListScopeConsumers_ListsAppClientsOfEveryAccountPerScope(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"ours":   {Name: "ours", Type: "backend", AccountId: "111111111111", Scopes: []string{"https://api.example.com/read", "https://api.example.com/write"}},
+			"theirs": {Name: "theirs", Type: "frontend", AccountId: "222222222222", Scopes: []string{"https://api.example.com/read"}},
+			"other":  {Name: "other", Type: "backend", AccountId: "222222222222", Scopes: []string{"https://other.example.com/read"}},
+		},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Scopes: []central_cognito.Scope{
+					{Name: "read", Description: "Read access"},
+					{Name: "write", Description: "Write access"},
+				},
+			},
+		},
+		PageSize: 1,
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	consumers, err := client.ListScopeConsumers(context.Background(), "https://api.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(consumers) != 3 {
+		t.Fatalf("expected 3 consumers, got %v", consumers)
+	}
+	expected := central_cognito.ScopeConsumer{Scope: "read", AppClientName: "theirs", AppClientType: "frontend", AccountId: "222222222222"}
+	if consumers[1] != expected {
+		t.Errorf("expected %+v, got %+v", expected, consumers[1])
+	}
+}
+
+func TestCreateAppClient_IsOwnedByTheCaller(t *testing.T) {
+	api := &fakes.CentralCognitoAPI{
+		AppClients:      map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{},
+		CallerAccountId: "111111111111",
+	}
+	server, client := api.Start()
+	defer server.Close()
+
+	created, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{Name: "ours", Type: "backend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.AccountId != "111111111111" {
+		t.Errorf("expected the app client to be owned by the caller, got %q", created.AccountId)
+	}
+}
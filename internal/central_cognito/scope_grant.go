@@ -0,0 +1,149 @@
+package central_cognito
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+// ScopeGrant lets app clients request a scope of a resource server they don't own.
+// Central cognito rejects app clients that request scopes of other teams without a grant.
+type ScopeGrant struct {
+	// Id is decided by the server when the grant is created.
+	Id string `json:"id,omitempty"`
+	// Scope is the full name of the granted scope, as `identifier/name`.
+	Scope string `json:"scope"`
+	// Exactly one of AppClientName and AppClientNamePattern is set.
+	AppClientName *string `json:"app_client_name,omitempty"`
+	// AppClientNamePattern is a glob, like `*.infrademo.vydev.io`, matching the names of the granted app clients.
+	AppClientNamePattern *string `json:"app_client_name_pattern,omitempty"`
+}
+
+// ScopeGrantRequest is made when an app client is rejected for requesting a scope that has not been granted to it.
+// It is pending until the owner of the resource server creates a grant that covers it.
+type ScopeGrantRequest struct {
+	Id            string `json:"id"`
+	Scope         string `json:"scope"`
+	AppClientName string `json:"app_client_name"`
+	// RequestedAt is when the app client first requested the scope, in RFC 3339 format.
+	RequestedAt string `json:"requested_at"`
+}
+
+func (c Client) CreateScopeGrant(ctx context.Context, grant ScopeGrant) (*ScopeGrant, error) {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	var data bytes.Buffer
+
+	err := json.NewEncoder(&data).Encode(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s%s/scope-grants", protocol, c.BaseUrl),
+		&data,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 201 {
+		return nil, api_errors.FromResponse("could not create resource", response)
+	}
+
+	var created ScopeGrant
+	err = json.NewDecoder(response.Body).Decode(&created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (c Client) ReadScopeGrant(ctx context.Context, id string, grant *ScopeGrant) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s%s/scope-grants/%s", protocol, c.BaseUrl, url.QueryEscape(id)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return api_errors.FromResponse("could not read resource", response)
+	}
+
+	err = json.NewDecoder(response.Body).Decode(grant)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c Client) DeleteScopeGrant(ctx context.Context, id string) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf("%s%s/scope-grants/%s", protocol, c.BaseUrl, url.QueryEscape(id)),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.send(request)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != 200 {
+		defer response.Body.Close()
+
+		return api_errors.FromResponse("could not delete resource", response)
+	}
+
+	return nil
+}
+
+// ListScopeGrantRequests reads the pending grant requests for scopes of the resource servers we own,
+// following the pagination of the API.
+func (c Client) ListScopeGrantRequests(ctx context.Context) ([]ScopeGrantRequest, error) {
+	return listAll[ScopeGrantRequest](ctx, c, "scope-grant-requests")
+}
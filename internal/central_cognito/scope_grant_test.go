@@ -0,0 +1,133 @@
+package central_cognito_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func newScopeGrantFake() *fakes.CentralCognitoAPI {
+	return &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{},
+		ResourceServers: map[string]central_cognito.ResourceServer{
+			"https://api.example.com": {
+				Identifier: "https://api.example.com",
+				Name:       "Example API",
+				Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read access"}},
+			},
+		},
+		RequireScopeGrants: true,
+	}
+}
+
+func TestCreateAppClient_RejectsUngrantedScopeAndRecordsRequest(t *testing.T) {
+	api := newScopeGrantFake()
+	server, client := api.Start()
+	defer server.Close()
+
+	_, err := client.CreateAppClient(context.Background(), central_cognito.AppClient{
+		Name:   "other-team",
+		Type:   "backend",
+		Scopes: []string{"https://api.example.com/read"},
+	})
+	if !errors.Is(err, api_errors.ErrUnauthorized) {
+		t.Fatalf("expected the scope to be rejected, got %v", err)
+	}
+
+	requests, err := client.ListScopeGrantRequests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 pending request, got %d", len(requests))
+	}
+	if requests[0].Scope != "https://api.example.com/read" || requests[0].AppClientName != "other-team" {
+		t.Errorf("unexpected request: %+v", requests[0])
+	}
+}
+
+func TestCreateScopeGrant_AllowsMatchingAppClientsAndResolvesRequests(t *testing.T) {
+	api := newScopeGrantFake()
+	server, client := api.Start()
+	defer server.Close()
+
+	_, _ = client.CreateAppClient(context.Background(), central_cognito.AppClient{
+		Name:   "client.other-team.vydev.io",
+		Type:   "backend",
+		Scopes: []string{"https://api.example.com/read"},
+	})
+
+	pattern := "*.other-team.vydev.io"
+	grant, err := client.CreateScopeGrant(context.Background(), central_cognito.ScopeGrant{
+		Scope:                "https://api.example.com/read",
+		AppClientNamePattern: &pattern,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if grant.Id == "" {
+		t.Errorf("expected the grant to get an id")
+	}
+
+	requests, err := client.ListScopeGrantRequests(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("expected the request to be resolved by the grant, got %v", requests)
+	}
+
+	_, err = client.CreateAppClient(context.Background(), central_cognito.AppClient{
+		Name:   "client.other-team.vydev.io",
+		Type:   "backend",
+		Scopes: []string{"https://api.example.com/read"},
+	})
+	if err != nil {
+		t.Fatalf("expected the granted scope to be allowed, got %v", err)
+	}
+}
+
+func TestCreateScopeGrant_RejectsUnknownScope(t *testing.T) {
+	api := newScopeGrantFake()
+	server, client := api.Start()
+	defer server.Close()
+
+	name := "other-team"
+	_, err := client.CreateScopeGrant(context.Background(), central_cognito.ScopeGrant{
+		Scope:         "https://api.example.com/write",
+		AppClientName: &name,
+	})
+	if err == nil {
+		t.Fatalf("expected an error for a scope that does not exist")
+	}
+}
+
+func TestDeleteScopeGrant_RemovesGrantSoReadReturnsNotFound(t *testing.T) {
+	api := newScopeGrantFake()
+	server, client := api.Start()
+	defer server.Close()
+
+	name := "other-team"
+	grant, err := client.CreateScopeGrant(context.Background(), central_cognito.ScopeGrant{
+		Scope:         "https://api.example.com/read",
+		AppClientName: &name,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = client.DeleteScopeGrant(context.Background(), grant.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result central_cognito.ScopeGrant
+	err = client.ReadScopeGrant(context.Background(), grant.Id, &result)
+	if !api_errors.IsNotFound(err) {
+		t.Fatalf("expected the grant to be gone, got %v", err)
+	}
+}
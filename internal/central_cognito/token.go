@@ -0,0 +1,55 @@
+package central_cognito
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+// AccessToken is the response of the token endpoint, as described in RFC 6749.
+type AccessToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is how many seconds the token is valid for.
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// RequestClientCredentialsToken gets an access token for the app client from the token endpoint of the user pool,
+// using the client_credentials grant. Without scopes, the token gets all the scopes of the app client.
+func (c Client) RequestClientCredentialsToken(ctx context.Context, tokenEndpoint string, clientId string, clientSecret string, scopes []string) (*AccessToken, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(clientId), url.QueryEscape(clientSecret))
+
+	response, err := c.sendUnsigned(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != 200 {
+		return nil, api_errors.FromResponse("could not get access token", response)
+	}
+
+	var token AccessToken
+	err = json.NewDecoder(response.Body).Decode(&token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,63 @@
+package central_cognito_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+func TestRequestClientCredentialsToken(t *testing.T) {
+	clientID := "backend-client-id"
+	secret := "backend-secret"
+	api := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"backend-app": {
+				Name:         "backend-app",
+				Scopes:       []string{"trains.example.com/read", "trains.example.com/write"},
+				ClientId:     &clientID,
+				ClientSecret: &secret,
+			},
+		},
+	}
+	server, client := api.Start()
+	defer server.Close()
+	tokenEndpoint := server.URL + "/oauth2/token"
+
+	tests := []struct {
+		name         string
+		clientSecret string
+		scopes       []string
+		wantError    string
+	}{
+		{name: "all scopes", clientSecret: secret},
+		{name: "some scopes", clientSecret: secret, scopes: []string{"trains.example.com/read"}},
+		{name: "wrong secret", clientSecret: "wrong", wantError: "invalid_client"},
+		{name: "scope not granted", clientSecret: secret, scopes: []string{"buses.example.com/read"}, wantError: "invalid_scope"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := client.RequestClientCredentialsToken(context.Background(), tokenEndpoint, clientID, tt.clientSecret, tt.scopes)
+
+			if tt.wantError != "" {
+				var apiErr *api_errors.Error
+				if !errors.As(err, &apiErr) || !strings.Contains(apiErr.Message, tt.wantError) {
+					t.Fatalf("expected an %s error, got: %v", tt.wantError, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(token.AccessToken, "fake-access-token-") || token.TokenType != "Bearer" || token.ExpiresIn != 3600 {
+				t.Errorf("unexpected token: %+v", token)
+			}
+		})
+	}
+}
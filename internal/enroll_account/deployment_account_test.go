@@ -0,0 +1,143 @@
+package enroll_account_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+const callerAccountId = "123456789012"
+
+func TestCreateDeploymentAccount(t *testing.T) {
+	tests := []struct {
+		name         string
+		existing     *enroll_account.DeploymentAccount
+		slackChannel string
+		want         *enroll_account.DeploymentAccount
+		wantErr      error
+	}{
+		{
+			name:         "enrolls the calling account",
+			slackChannel: "#deployments",
+			want:         &enroll_account.DeploymentAccount{AccountId: callerAccountId, SlackChannel: "#deployments"},
+		},
+		{
+			name:         "returns a conflict when the account is already enrolled",
+			existing:     &enroll_account.DeploymentAccount{AccountId: callerAccountId, SlackChannel: "#old"},
+			slackChannel: "#deployments",
+			wantErr:      api_errors.ErrConflict,
+		},
+		{
+			name:         "returns an error when the slack channel is missing",
+			slackChannel: "",
+			wantErr:      errBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, DeploymentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			got, err := client.CreateDeploymentAccount(context.Background(), tt.slackChannel)
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("account = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDeploymentAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *enroll_account.DeploymentAccount
+		want     enroll_account.DeploymentAccount
+		wantErr  error
+	}{
+		{
+			name:     "returns the enrolled account",
+			existing: &enroll_account.DeploymentAccount{AccountId: callerAccountId, SlackChannel: "#deployments"},
+			want:     enroll_account.DeploymentAccount{AccountId: callerAccountId, SlackChannel: "#deployments"},
+		},
+		{
+			name:    "returns not found when the account is not enrolled",
+			wantErr: api_errors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, DeploymentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			var got enroll_account.DeploymentAccount
+			err := client.ReadDeploymentAccount(context.Background(), &got)
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("account = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteDeploymentAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *enroll_account.DeploymentAccount
+		wantErr  error
+	}{
+		{
+			name:     "removes the enrolled account",
+			existing: &enroll_account.DeploymentAccount{AccountId: callerAccountId, SlackChannel: "#deployments"},
+		},
+		{
+			name:    "returns not found when the account is not enrolled",
+			wantErr: api_errors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, DeploymentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			err := client.DeleteDeploymentAccount(context.Background())
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			var account enroll_account.DeploymentAccount
+			if err := client.ReadDeploymentAccount(context.Background(), &account); !api_errors.IsNotFound(err) {
+				t.Errorf("read after delete: err = %v, want a not found error", err)
+			}
+		})
+	}
+}
+
+// errBadRequest matches any API error with a 400 status, which has no sentinel of its own.
+var errBadRequest = errors.New("bad request")
+
+func matchesError(err, want error) bool {
+	if want == nil {
+		return err == nil
+	}
+
+	if want == errBadRequest {
+		var apiErr *api_errors.Error
+		return errors.As(err, &apiErr) && apiErr.StatusCode == 400
+	}
+
+	return errors.Is(err, want)
+}
@@ -1,5 +1,34 @@
 package enroll_account
 
+import (
+	"net/http"
+	"time"
+
+	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
+)
+
 type Client struct {
-	BaseUrl string
+	BaseUrl        string
+	HTTPClient     *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	Signer         *aws_auth.Signer
+	Retry          transport.RetryConfig
+	RequestTimeout time.Duration
+}
+
+// logSubsystem is the tflog subsystem that requests to this service are logged to.
+const logSubsystem = "enroll_account"
+
+func (c Client) send(request *http.Request) (*http.Response, error) {
+	t := transport.Transport{
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Timeout:    c.RequestTimeout,
+		Subsystem:  logSubsystem,
+	}
+	if c.HTTPClient == nil {
+		t.Sign = c.Signer.Sign
+	}
+
+	return t.Do(request)
 }
@@ -2,13 +2,12 @@ package enroll_account
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 
-	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 )
 
 type EnvironmentAccount struct {
@@ -20,7 +19,7 @@ type EnvironmentAccountCreateRequest struct {
 	OwnerAccountId string `json:"owner_account_id"`
 }
 
-func (c Client) RegisterEnvironmentAccount(ownerAccountId string) (*EnvironmentAccount, error) {
+func (c Client) RegisterEnvironmentAccount(ctx context.Context, ownerAccountId string) (*EnvironmentAccount, error) {
 	var data bytes.Buffer
 
 	err := json.NewEncoder(&data).Encode(EnvironmentAccountCreateRequest{OwnerAccountId: ownerAccountId})
@@ -28,16 +27,22 @@ func (c Client) RegisterEnvironmentAccount(ownerAccountId string) (*EnvironmentA
 		return nil, err
 	}
 
-	request, err := http.NewRequest(
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodPost,
-		fmt.Sprintf("https://%s/environment_accounts", c.BaseUrl),
+		fmt.Sprintf("%s%s/environment_accounts", protocol, c.BaseUrl),
 		&data,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := aws_auth.SignedRequest(request)
+	response, err := c.send(request)
 	if err != nil {
 		return nil, err
 	}
@@ -45,9 +50,7 @@ func (c Client) RegisterEnvironmentAccount(ownerAccountId string) (*EnvironmentA
 	if response.StatusCode != 201 {
 		defer response.Body.Close()
 
-		str, _ := io.ReadAll(response.Body)
-
-		return nil, errors.New(fmt.Sprintf("Could not add account. %s", str))
+		return nil, api_errors.FromResponse("Could not add account", response)
 	}
 
 	var createdAccount *EnvironmentAccount
@@ -59,17 +62,23 @@ func (c Client) RegisterEnvironmentAccount(ownerAccountId string) (*EnvironmentA
 	return createdAccount, nil
 }
 
-func (c Client) ReadEnvironmentAccount(account *EnvironmentAccount) error {
-	request, err := http.NewRequest(
+func (c Client) ReadEnvironmentAccount(ctx context.Context, account *EnvironmentAccount) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("https://%s/environment_accounts", c.BaseUrl),
+		fmt.Sprintf("%s%s/environment_accounts", protocol, c.BaseUrl),
 		nil,
 	)
 	if err != nil {
 		return err
 	}
 
-	response, err := aws_auth.SignedRequest(request)
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -77,9 +86,7 @@ func (c Client) ReadEnvironmentAccount(account *EnvironmentAccount) error {
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not read environment account. %s", str))
+		return api_errors.FromResponse("could not read environment account", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(account)
@@ -90,17 +97,23 @@ func (c Client) ReadEnvironmentAccount(account *EnvironmentAccount) error {
 	return nil
 }
 
-func (c Client) DeleteEnvironmentAccount() error {
-	request, err := http.NewRequest(
+func (c Client) DeleteEnvironmentAccount(ctx context.Context) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodDelete,
-		fmt.Sprintf("https://%s/environment_accounts", c.BaseUrl),
+		fmt.Sprintf("%s%s/environment_accounts", protocol, c.BaseUrl),
 		nil,
 	)
 	if err != nil {
 		return err
 	}
 
-	response, err := aws_auth.SignedRequest(request)
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -108,9 +121,7 @@ func (c Client) DeleteEnvironmentAccount() error {
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not delete account. %s", str))
+		return api_errors.FromResponse("could not delete account", response)
 	}
 
 	return nil
@@ -0,0 +1,173 @@
+package enroll_account_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+)
+
+const ownerAccountId = "210987654321"
+
+func TestRegisterEnvironmentAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		existing       *enroll_account.EnvironmentAccount
+		ownerAccountId string
+		want           *enroll_account.EnvironmentAccount
+		wantErr        error
+	}{
+		{
+			name:           "registers the calling account under the owner",
+			ownerAccountId: ownerAccountId,
+			want:           &enroll_account.EnvironmentAccount{AccountId: callerAccountId, OwnerAccountId: ownerAccountId},
+		},
+		{
+			name:           "returns a conflict when the account is already registered",
+			existing:       &enroll_account.EnvironmentAccount{AccountId: callerAccountId, OwnerAccountId: "000000000000"},
+			ownerAccountId: ownerAccountId,
+			wantErr:        api_errors.ErrConflict,
+		},
+		{
+			name:           "returns an error when the owner account is missing",
+			ownerAccountId: "",
+			wantErr:        errBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, EnvironmentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			got, err := client.RegisterEnvironmentAccount(context.Background(), tt.ownerAccountId)
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("account = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEnvironmentAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *enroll_account.EnvironmentAccount
+		want     enroll_account.EnvironmentAccount
+		wantErr  error
+	}{
+		{
+			name:     "returns the registered account",
+			existing: &enroll_account.EnvironmentAccount{AccountId: callerAccountId, OwnerAccountId: ownerAccountId},
+			want:     enroll_account.EnvironmentAccount{AccountId: callerAccountId, OwnerAccountId: ownerAccountId},
+		},
+		{
+			name:    "returns not found when the account is not registered",
+			wantErr: api_errors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, EnvironmentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			var got enroll_account.EnvironmentAccount
+			err := client.ReadEnvironmentAccount(context.Background(), &got)
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("account = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteEnvironmentAccount(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing *enroll_account.EnvironmentAccount
+		wantErr  error
+	}{
+		{
+			name:     "removes the registered account",
+			existing: &enroll_account.EnvironmentAccount{AccountId: callerAccountId, OwnerAccountId: ownerAccountId},
+		},
+		{
+			name:    "returns not found when the account is not registered",
+			wantErr: api_errors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.EnrollAccountAPI{CallerAccountId: callerAccountId, EnvironmentAccount: tt.existing}
+			server, client := api.Start()
+			defer server.Close()
+
+			err := client.DeleteEnvironmentAccount(context.Background())
+			if !matchesError(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			var account enroll_account.EnvironmentAccount
+			if err := client.ReadEnvironmentAccount(context.Background(), &account); !api_errors.IsNotFound(err) {
+				t.Errorf("read after delete: err = %v, want a not found error", err)
+			}
+		})
+	}
+}
+
+func TestEnvironmentAccount_PropagatesServerErrorWithStatusAndMessage(t *testing.T) {
+	brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(api_errors.Payload{
+			Message:   "deployment service unavailable",
+			ErrorType: "INTERNAL_ERROR",
+		})
+	}))
+	defer brokenServer.Close()
+
+	client := &enroll_account.Client{
+		BaseUrl:    strings.TrimPrefix(brokenServer.URL, "http://"),
+		HTTPClient: brokenServer.Client(),
+	}
+
+	operations := map[string]func() error{
+		"register": func() error {
+			_, err := client.RegisterEnvironmentAccount(context.Background(), ownerAccountId)
+			return err
+		},
+		"read": func() error {
+			return client.ReadEnvironmentAccount(context.Background(), &enroll_account.EnvironmentAccount{})
+		},
+		"delete": func() error {
+			return client.DeleteEnvironmentAccount(context.Background())
+		},
+	}
+
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			err := operation()
+			if !errors.Is(err, api_errors.ErrServerError) {
+				t.Fatalf("err = %v, want a server error", err)
+			}
+			if !strings.Contains(err.Error(), "deployment service unavailable") {
+				t.Errorf("error = %q, want it to contain the API error message", err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,934 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+// CentralCognitoAPI is an in-memory HTTP fake that emulates the central Cognito API.
+type CentralCognitoAPI struct {
+	AppClients      map[string]central_cognito.AppClient      // name → AppClient
+	ResourceServers map[string]central_cognito.ResourceServer // identifier → ResourceServer
+	// IdentityProviders are the providers app clients can sign in with. Others are rejected.
+	IdentityProviders map[string]central_cognito.IdentityProvider // name → IdentityProvider
+
+	// RequireScopeGrants rejects app clients that request scopes of resource servers without a grant for them,
+	// as if every resource server belonged to another team. The rejected requests are listed as pending.
+	RequireScopeGrants bool
+	ScopeGrants        map[string]central_cognito.ScopeGrant        // id → ScopeGrant
+	ScopeGrantRequests map[string]central_cognito.ScopeGrantRequest // id → ScopeGrantRequest
+
+	// CallerAccountId is the account of whoever calls the API, which owns the app clients it creates.
+	CallerAccountId string
+
+	// PageSize is how many items are listed per page. Defaults to 100.
+	PageSize int
+
+	// Info is served as the discovery document, and as the /.well-known/openid-configuration of the user pool.
+	// Without it, the environment is unknown.
+	Info *central_cognito.CognitoInfo
+	// Jwks is served at /.well-known/jwks.json, like the JWKS of a user pool.
+	Jwks *central_cognito.JsonWebKeySet
+
+	mu        sync.Mutex
+	rotations int
+	tokens    int
+	grants    int
+}
+
+func (api *CentralCognitoAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	rawPath := r.URL.RawPath
+	if rawPath == "" {
+		rawPath = r.URL.Path
+	}
+	path := strings.TrimPrefix(rawPath, "/")
+	segments := strings.SplitN(path, "/", 4)
+
+	switch {
+	case r.Method == http.MethodGet && path == "discovery":
+		if api.Info == nil {
+			respondWithError(w, http.StatusNotFound, "no user pool in this environment", "NOT_FOUND")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, api.Info)
+
+	case r.Method == http.MethodGet && path == ".well-known/openid-configuration":
+		if api.Info == nil {
+			respondWithError(w, http.StatusNotFound, "no user pool in this environment", "NOT_FOUND")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, central_cognito.OpenIdConfiguration{
+			Issuer:                api.Info.Issuer,
+			AuthorizationEndpoint: api.Info.AuthUrl + "/oauth2/authorize",
+			TokenEndpoint:         api.Info.TokenEndpoint,
+			UserinfoEndpoint:      api.Info.UserinfoEndpoint,
+			EndSessionEndpoint:    api.Info.LogoutEndpoint,
+			JwksUri:               api.Info.JwksUrl,
+			ScopesSupported:       api.Info.ScopesSupported,
+		})
+
+	case r.Method == http.MethodGet && path == ".well-known/jwks.json":
+		if api.Jwks == nil {
+			respondWithError(w, http.StatusNotFound, "no signing keys", "NOT_FOUND")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, api.Jwks)
+
+	case r.Method == http.MethodPost && path == "oauth2/token":
+		api.handleToken(w, r)
+
+	case r.Method == http.MethodPost && path == "import/app-client":
+		api.handleImportAppClient(w, r)
+
+	case r.Method == http.MethodPost && path == "import/resource-server":
+		api.handleImportResourceServer(w, r)
+
+	case r.Method == http.MethodGet && path == "app-clients":
+		respondWithPage(w, r, api.PageSize, api.AppClients)
+
+	case r.Method == http.MethodGet && path == "identity-providers":
+		respondWithPage(w, r, api.PageSize, api.IdentityProviders)
+
+	case r.Method == http.MethodPost && path == "app-clients":
+		api.handleCreateAppClient(w, r)
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "app-clients" && segments[1] == "by-client-id":
+		clientId, err := url.QueryUnescape(segments[2])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		api.handleReadAppClientByClientId(w, clientId)
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[0] == "app-clients" && segments[2] == "rotate-secret":
+		name, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		api.handleRotateAppClientSecret(w, r, name)
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[0] == "app-clients" && segments[2] == "rename":
+		name, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		api.handleRenameAppClient(w, r, name)
+
+	case len(segments) == 2 && segments[0] == "app-clients":
+		name, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.handleReadAppClient(w, name)
+		case http.MethodPut:
+			api.handleUpdateAppClient(w, r, name)
+		case http.MethodDelete:
+			api.handleDeleteAppClient(w, name)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	case r.Method == http.MethodGet && path == "scope-grant-requests":
+		respondWithPage(w, r, api.PageSize, api.ScopeGrantRequests)
+
+	case r.Method == http.MethodPost && path == "scope-grants":
+		api.handleCreateScopeGrant(w, r)
+
+	case len(segments) == 2 && segments[0] == "scope-grants":
+		id, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.handleReadScopeGrant(w, id)
+		case http.MethodDelete:
+			api.handleDeleteScopeGrant(w, id)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	case r.Method == http.MethodGet && path == "resource-servers":
+		respondWithPage(w, r, api.PageSize, api.ResourceServers)
+
+	case r.Method == http.MethodPost && path == "resource-servers":
+		api.handleCreateResourceServer(w, r)
+
+	case r.Method == http.MethodPost && len(segments) == 3 && segments[0] == "resource-servers" && segments[2] == "scopes":
+		identifier, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		api.handleCreateResourceServerScope(w, r, identifier)
+
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[0] == "resource-servers" && segments[2] == "consumers":
+		identifier, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		api.handleListScopeConsumers(w, r, identifier)
+
+	case len(segments) == 4 && segments[0] == "resource-servers" && segments[2] == "scopes":
+		identifier, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		name, err := url.QueryUnescape(segments[3])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.handleReadResourceServerScope(w, identifier, name)
+		case http.MethodPut:
+			api.handleUpdateResourceServerScope(w, r, identifier, name)
+		case http.MethodDelete:
+			api.handleDeleteResourceServerScope(w, identifier, name)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	case len(segments) == 2 && segments[0] == "resource-servers":
+		identifier, err := url.QueryUnescape(segments[1])
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid URL encoding", "BAD_REQUEST")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.handleReadResourceServer(w, identifier)
+		case http.MethodPut:
+			api.handleUpdateResourceServer(w, r, identifier)
+		case http.MethodDelete:
+			api.handleDeleteResourceServer(w, identifier)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	default:
+		respondWithError(w, http.StatusNotFound, "unknown endpoint: "+r.URL.Path, "NOT_FOUND")
+	}
+}
+
+func (api *CentralCognitoAPI) handleReadAppClient(w http.ResponseWriter, name string) {
+	ac, ok := api.AppClients[name]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client %q not found", name), "NOT_FOUND")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, ac)
+}
+
+func (api *CentralCognitoAPI) handleReadAppClientByClientId(w http.ResponseWriter, clientId string) {
+	for _, ac := range api.AppClients {
+		if ac.ClientId != nil && *ac.ClientId == clientId {
+			respondWithJSON(w, http.StatusOK, ac)
+			return
+		}
+	}
+
+	respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client with client_id %q not found", clientId), "NOT_FOUND")
+}
+
+func (api *CentralCognitoAPI) handleCreateAppClient(w http.ResponseWriter, r *http.Request) {
+	var ac central_cognito.AppClient
+	if err := json.NewDecoder(r.Body).Decode(&ac); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	if _, exists := api.AppClients[ac.Name]; exists {
+		respondWithError(w, http.StatusConflict, fmt.Sprintf("app client %q already exists", ac.Name), "CONFLICT")
+		return
+	}
+
+	// Like the real API, the secret is decided by the type unless it is set explicitly.
+	if ac.GenerateSecret == nil {
+		generateSecret := ac.Type == "backend"
+		ac.GenerateSecret = &generateSecret
+	}
+
+	if name, ok := api.unknownIdentityProvider(ac.SupportedIdentityProviders); !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("identity provider %q does not exist", name), "BAD_REQUEST")
+		return
+	}
+
+	if scope, ok := api.missingScope(ac.Scopes); !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("scope %q does not exist", scope), "BAD_REQUEST")
+		return
+	}
+
+	if scope, ok := api.ungrantedScope(ac.Name, ac.Scopes); !ok {
+		respondWithError(w, http.StatusForbidden, fmt.Sprintf("scope %q has not been granted to app client %q", scope, ac.Name), "SCOPE_NOT_GRANTED")
+		return
+	}
+
+	applyAppClientDefaults(&ac)
+	ac.CallbackUrls = normalizeUrls(ac.CallbackUrls)
+	ac.LogoutUrls = normalizeUrls(ac.LogoutUrls)
+	ac.AccountId = api.CallerAccountId
+
+	clientID := "generated-client-id-" + ac.Name
+	ac.ClientId = &clientID
+
+	if ac.GenerateSecret != nil && *ac.GenerateSecret {
+		secret := "generated-secret-for-" + ac.Name
+		createdAt := time.Now().UTC().Format(time.RFC3339)
+		ac.ClientSecret = &secret
+		ac.ClientSecretCreatedAt = &createdAt
+	}
+
+	api.AppClients[ac.Name] = ac
+	respondWithJSON(w, http.StatusCreated, ac)
+}
+
+// normalizeUrls trims the whitespace around each URL, like the real API does before storing them.
+func normalizeUrls(urls []string) []string {
+	if urls == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(urls))
+	for _, u := range urls {
+		normalized = append(normalized, strings.TrimSpace(u))
+	}
+
+	return normalized
+}
+
+// applyAppClientDefaults fills in the settings that were not set, the same way the real API derives them from the type.
+func applyAppClientDefaults(ac *central_cognito.AppClient) {
+	if ac.AccessTokenValidityMinutes == nil {
+		minutes := int64(60)
+		ac.AccessTokenValidityMinutes = &minutes
+	}
+	if ac.IdTokenValidityMinutes == nil {
+		minutes := int64(60)
+		ac.IdTokenValidityMinutes = &minutes
+	}
+	if ac.RefreshTokenValidityDays == nil {
+		days := int64(30)
+		ac.RefreshTokenValidityDays = &days
+	}
+	if ac.AllowedOAuthFlows == nil {
+		if ac.Type == "backend" {
+			ac.AllowedOAuthFlows = &[]string{"client_credentials"}
+		} else {
+			ac.AllowedOAuthFlows = &[]string{"code"}
+		}
+	}
+	if ac.PreventUserExistenceErrors == nil {
+		prevent := true
+		ac.PreventUserExistenceErrors = &prevent
+	}
+	if ac.EnableTokenRevocation == nil {
+		enable := true
+		ac.EnableTokenRevocation = &enable
+	}
+	// Only users sign in through identity providers, so backend clients have none.
+	if ac.SupportedIdentityProviders == nil && ac.Type == "frontend" {
+		ac.SupportedIdentityProviders = &[]string{"COGNITO"}
+	}
+}
+
+// unknownIdentityProvider returns the first of the names that is not a registered identity provider.
+func (api *CentralCognitoAPI) unknownIdentityProvider(names *[]string) (string, bool) {
+	if names == nil {
+		return "", true
+	}
+
+	for _, name := range *names {
+		if _, exists := api.IdentityProviders[name]; !exists {
+			return name, false
+		}
+	}
+
+	return "", true
+}
+
+func (api *CentralCognitoAPI) handleUpdateAppClient(w http.ResponseWriter, r *http.Request, name string) {
+	var req central_cognito.AppClientUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, ok := api.AppClients[name]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client %q not found", name), "NOT_FOUND")
+		return
+	}
+
+	if name, ok := api.unknownIdentityProvider(req.SupportedIdentityProviders); !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("identity provider %q does not exist", name), "BAD_REQUEST")
+		return
+	}
+
+	if scope, ok := api.missingScope(req.Scopes); !ok {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("scope %q does not exist", scope), "BAD_REQUEST")
+		return
+	}
+
+	if scope, ok := api.ungrantedScope(name, req.Scopes); !ok {
+		respondWithError(w, http.StatusForbidden, fmt.Sprintf("scope %q has not been granted to app client %q", scope, name), "SCOPE_NOT_GRANTED")
+		return
+	}
+
+	existing.Scopes = req.Scopes
+	existing.CallbackUrls = normalizeUrls(req.CallbackUrls)
+	existing.LogoutUrls = normalizeUrls(req.LogoutUrls)
+	if req.AccessTokenValidityMinutes != nil {
+		existing.AccessTokenValidityMinutes = req.AccessTokenValidityMinutes
+	}
+	if req.IdTokenValidityMinutes != nil {
+		existing.IdTokenValidityMinutes = req.IdTokenValidityMinutes
+	}
+	if req.RefreshTokenValidityDays != nil {
+		existing.RefreshTokenValidityDays = req.RefreshTokenValidityDays
+	}
+	if req.AllowedOAuthFlows != nil {
+		existing.AllowedOAuthFlows = req.AllowedOAuthFlows
+	}
+	if req.PreventUserExistenceErrors != nil {
+		existing.PreventUserExistenceErrors = req.PreventUserExistenceErrors
+	}
+	if req.EnableTokenRevocation != nil {
+		existing.EnableTokenRevocation = req.EnableTokenRevocation
+	}
+	if req.DefaultRedirectUri != nil {
+		existing.DefaultRedirectUri = req.DefaultRedirectUri
+	}
+	if req.SupportedIdentityProviders != nil {
+		existing.SupportedIdentityProviders = req.SupportedIdentityProviders
+	}
+	api.AppClients[name] = existing
+
+	respondWithJSON(w, http.StatusOK, existing)
+}
+
+// ungrantedScope returns the first of the scopes of a resource server that has not been granted to the app client.
+// Like the real API, each rejected scope is recorded as a pending grant request.
+func (api *CentralCognitoAPI) ungrantedScope(appClientName string, scopes []string) (string, bool) {
+	if !api.RequireScopeGrants {
+		return "", true
+	}
+
+	for _, scope := range scopes {
+		if !api.isResourceServerScope(scope) || api.isGranted(scope, appClientName) {
+			continue
+		}
+
+		if api.ScopeGrantRequests == nil {
+			api.ScopeGrantRequests = map[string]central_cognito.ScopeGrantRequest{}
+		}
+		id := appClientName + " " + scope
+		if _, exists := api.ScopeGrantRequests[id]; !exists {
+			api.ScopeGrantRequests[id] = central_cognito.ScopeGrantRequest{
+				Id:            id,
+				Scope:         scope,
+				AppClientName: appClientName,
+				RequestedAt:   time.Now().UTC().Format(time.RFC3339),
+			}
+		}
+
+		return scope, false
+	}
+
+	return "", true
+}
+
+// missingScope returns the first of the scopes that belongs to an existing resource server, which does not have it.
+func (api *CentralCognitoAPI) missingScope(scopes []string) (string, bool) {
+	for _, scope := range scopes {
+		if api.isResourceServerScope(scope) {
+			continue
+		}
+
+		for identifier := range api.ResourceServers {
+			if strings.HasPrefix(scope, identifier+"/") {
+				return scope, false
+			}
+		}
+	}
+
+	return "", true
+}
+
+func (api *CentralCognitoAPI) isResourceServerScope(scope string) bool {
+	for identifier, rs := range api.ResourceServers {
+		for _, s := range rs.Scopes {
+			if identifier+"/"+s.Name == scope {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (api *CentralCognitoAPI) isGranted(scope string, appClientName string) bool {
+	for _, grant := range api.ScopeGrants {
+		if grantCovers(grant, scope, appClientName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func grantCovers(grant central_cognito.ScopeGrant, scope string, appClientName string) bool {
+	if grant.Scope != scope {
+		return false
+	}
+	if grant.AppClientName != nil {
+		return *grant.AppClientName == appClientName
+	}
+	if grant.AppClientNamePattern != nil {
+		matches, _ := path.Match(*grant.AppClientNamePattern, appClientName)
+		return matches
+	}
+
+	return false
+}
+
+func (api *CentralCognitoAPI) handleRotateAppClientSecret(w http.ResponseWriter, r *http.Request, name string) {
+	var req central_cognito.RotateAppClientSecretRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, ok := api.AppClients[name]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client %q not found", name), "NOT_FOUND")
+		return
+	}
+	if existing.ClientSecret == nil {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("app client %q has no secret to rotate", name), "BAD_REQUEST")
+		return
+	}
+
+	api.rotations++
+	now := time.Now().UTC()
+	rotation := central_cognito.AppClientSecretRotation{
+		ClientSecret:            fmt.Sprintf("rotated-secret-%d-for-%s", api.rotations, name),
+		ClientSecretCreatedAt:   now.Format(time.RFC3339),
+		PreviousSecretExpiresAt: now.Add(time.Duration(req.OverlapSeconds) * time.Second).Format(time.RFC3339),
+	}
+
+	existing.ClientSecret = &rotation.ClientSecret
+	existing.ClientSecretCreatedAt = &rotation.ClientSecretCreatedAt
+	api.AppClients[name] = existing
+
+	respondWithJSON(w, http.StatusOK, rotation)
+}
+
+func (api *CentralCognitoAPI) handleRenameAppClient(w http.ResponseWriter, r *http.Request, name string) {
+	var req central_cognito.RenameAppClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, ok := api.AppClients[name]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client %q not found", name), "NOT_FOUND")
+		return
+	}
+	if _, exists := api.AppClients[req.NewName]; exists {
+		respondWithError(w, http.StatusConflict, fmt.Sprintf("app client %q already exists", req.NewName), "CONFLICT")
+		return
+	}
+
+	existing.Name = req.NewName
+	delete(api.AppClients, name)
+	api.AppClients[req.NewName] = existing
+
+	respondWithJSON(w, http.StatusOK, existing)
+}
+
+func (api *CentralCognitoAPI) handleDeleteAppClient(w http.ResponseWriter, name string) {
+	if _, ok := api.AppClients[name]; !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client %q not found", name), "NOT_FOUND")
+		return
+	}
+	delete(api.AppClients, name)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (api *CentralCognitoAPI) handleImportAppClient(w http.ResponseWriter, r *http.Request) {
+	var req central_cognito.ImportAppClientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	for _, ac := range api.AppClients {
+		if ac.ClientId != nil && *ac.ClientId == req.ClientId {
+			respondWithJSON(w, http.StatusOK, ac)
+			return
+		}
+	}
+
+	respondWithError(w, http.StatusNotFound, fmt.Sprintf("app client with client_id %q not found", req.ClientId), "NOT_FOUND")
+}
+
+// handleToken is the token endpoint of the user pool. Like Cognito, it only supports the client_credentials grant
+// for app clients with a secret, and responds with OAuth errors instead of our API errors.
+func (api *CentralCognitoAPI) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		respondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" {
+		respondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientId, clientSecret, _ := r.BasicAuth()
+	clientId, _ = url.QueryUnescape(clientId)
+	clientSecret, _ = url.QueryUnescape(clientSecret)
+
+	var client *central_cognito.AppClient
+	for _, ac := range api.AppClients {
+		if ac.ClientId != nil && *ac.ClientId == clientId && ac.ClientSecret != nil && *ac.ClientSecret == clientSecret {
+			client = &ac
+			break
+		}
+	}
+	if client == nil {
+		respondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	for _, requested := range strings.Fields(r.PostForm.Get("scope")) {
+		if !slices.Contains(client.Scopes, requested) {
+			respondWithJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_scope"})
+			return
+		}
+	}
+
+	api.tokens++
+	respondWithJSON(w, http.StatusOK, central_cognito.AccessToken{
+		AccessToken: fmt.Sprintf("fake-access-token-%d-for-%s", api.tokens, client.Name),
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+	})
+}
+
+func (api *CentralCognitoAPI) handleReadResourceServer(w http.ResponseWriter, identifier string) {
+	rs, ok := api.ResourceServers[identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, rs)
+}
+
+func (api *CentralCognitoAPI) handleCreateResourceServer(w http.ResponseWriter, r *http.Request) {
+	var rs central_cognito.ResourceServer
+	if err := json.NewDecoder(r.Body).Decode(&rs); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	if _, exists := api.ResourceServers[rs.Identifier]; exists {
+		respondWithError(w, http.StatusConflict, fmt.Sprintf("resource server %q already exists", rs.Identifier), "CONFLICT")
+		return
+	}
+
+	rs.Name = strings.TrimSpace(rs.Name)
+	rs.Scopes = normalizeScopes(rs.Scopes)
+
+	api.ResourceServers[rs.Identifier] = rs
+	respondWithJSON(w, http.StatusCreated, rs)
+}
+
+// normalizeScopes trims the whitespace around each description, like the real API does before storing them.
+func normalizeScopes(scopes []central_cognito.Scope) []central_cognito.Scope {
+	if scopes == nil {
+		return nil
+	}
+
+	normalized := make([]central_cognito.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		scope.Description = strings.TrimSpace(scope.Description)
+		normalized = append(normalized, scope)
+	}
+
+	return normalized
+}
+
+func (api *CentralCognitoAPI) handleUpdateResourceServer(w http.ResponseWriter, r *http.Request, identifier string) {
+	var req central_cognito.ResourceServerUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, ok := api.ResourceServers[identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return
+	}
+
+	existing.Name = strings.TrimSpace(req.Name)
+	if req.Scopes != nil {
+		existing.Scopes = normalizeScopes(req.Scopes)
+	}
+	api.ResourceServers[identifier] = existing
+
+	respondWithJSON(w, http.StatusOK, existing)
+}
+
+func (api *CentralCognitoAPI) handleDeleteResourceServer(w http.ResponseWriter, identifier string) {
+	if _, ok := api.ResourceServers[identifier]; !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return
+	}
+	delete(api.ResourceServers, identifier)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleListScopeConsumers lists the app clients holding each scope of the resource server, across all accounts.
+func (api *CentralCognitoAPI) handleListScopeConsumers(w http.ResponseWriter, r *http.Request, identifier string) {
+	rs, ok := api.ResourceServers[identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return
+	}
+
+	consumers := map[string]central_cognito.ScopeConsumer{}
+	for _, scope := range rs.Scopes {
+		for _, ac := range api.AppClients {
+			if !slices.Contains(ac.Scopes, identifier+"/"+scope.Name) {
+				continue
+			}
+			consumers[scope.Name+" "+ac.Name] = central_cognito.ScopeConsumer{
+				Scope:         scope.Name,
+				AppClientName: ac.Name,
+				AppClientType: ac.Type,
+				AccountId:     ac.AccountId,
+			}
+		}
+	}
+
+	respondWithPage(w, r, api.PageSize, consumers)
+}
+
+func (api *CentralCognitoAPI) handleCreateResourceServerScope(w http.ResponseWriter, r *http.Request, identifier string) {
+	var scope central_cognito.Scope
+	if err := json.NewDecoder(r.Body).Decode(&scope); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, ok := api.ResourceServers[identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return
+	}
+	if slices.ContainsFunc(existing.Scopes, func(s central_cognito.Scope) bool { return s.Name == scope.Name }) {
+		respondWithError(w, http.StatusConflict, fmt.Sprintf("scope %q already exists in resource server %q", scope.Name, identifier), "CONFLICT")
+		return
+	}
+
+	scope.Description = strings.TrimSpace(scope.Description)
+	existing.Scopes = append(slices.Clone(existing.Scopes), scope)
+	api.ResourceServers[identifier] = existing
+
+	respondWithJSON(w, http.StatusCreated, scope)
+}
+
+// findScope returns the resource server and the index of the named scope in it, or responds with not found.
+func (api *CentralCognitoAPI) findScope(w http.ResponseWriter, identifier string, name string) (central_cognito.ResourceServer, int, bool) {
+	existing, ok := api.ResourceServers[identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", identifier), "NOT_FOUND")
+		return central_cognito.ResourceServer{}, 0, false
+	}
+
+	index := slices.IndexFunc(existing.Scopes, func(s central_cognito.Scope) bool { return s.Name == name })
+	if index < 0 {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("scope %q not found in resource server %q", name, identifier), "NOT_FOUND")
+		return central_cognito.ResourceServer{}, 0, false
+	}
+
+	return existing, index, true
+}
+
+func (api *CentralCognitoAPI) handleReadResourceServerScope(w http.ResponseWriter, identifier string, name string) {
+	existing, index, ok := api.findScope(w, identifier, name)
+	if !ok {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, existing.Scopes[index])
+}
+
+func (api *CentralCognitoAPI) handleUpdateResourceServerScope(w http.ResponseWriter, r *http.Request, identifier string, name string) {
+	var scope central_cognito.Scope
+	if err := json.NewDecoder(r.Body).Decode(&scope); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	existing, index, ok := api.findScope(w, identifier, name)
+	if !ok {
+		return
+	}
+
+	existing.Scopes = slices.Clone(existing.Scopes)
+	existing.Scopes[index].Description = strings.TrimSpace(scope.Description)
+	api.ResourceServers[identifier] = existing
+
+	respondWithJSON(w, http.StatusOK, existing.Scopes[index])
+}
+
+func (api *CentralCognitoAPI) handleDeleteResourceServerScope(w http.ResponseWriter, identifier string, name string) {
+	existing, index, ok := api.findScope(w, identifier, name)
+	if !ok {
+		return
+	}
+
+	existing.Scopes = slices.Delete(slices.Clone(existing.Scopes), index, index+1)
+	api.ResourceServers[identifier] = existing
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (api *CentralCognitoAPI) handleCreateScopeGrant(w http.ResponseWriter, r *http.Request) {
+	var grant central_cognito.ScopeGrant
+	if err := json.NewDecoder(r.Body).Decode(&grant); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	if (grant.AppClientName == nil) == (grant.AppClientNamePattern == nil) {
+		respondWithError(w, http.StatusBadRequest, "exactly one of app_client_name and app_client_name_pattern must be set", "BAD_REQUEST")
+		return
+	}
+	if grant.AppClientNamePattern != nil {
+		if _, err := path.Match(*grant.AppClientNamePattern, ""); err != nil {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid app_client_name_pattern %q", *grant.AppClientNamePattern), "BAD_REQUEST")
+			return
+		}
+	}
+	if !api.isResourceServerScope(grant.Scope) {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("scope %q does not exist", grant.Scope), "BAD_REQUEST")
+		return
+	}
+
+	if api.ScopeGrants == nil {
+		api.ScopeGrants = map[string]central_cognito.ScopeGrant{}
+	}
+	api.grants++
+	grant.Id = fmt.Sprintf("grant-%d", api.grants)
+	api.ScopeGrants[grant.Id] = grant
+
+	// The requests that are covered by the grant are no longer pending.
+	for id, request := range api.ScopeGrantRequests {
+		if grantCovers(grant, request.Scope, request.AppClientName) {
+			delete(api.ScopeGrantRequests, id)
+		}
+	}
+
+	respondWithJSON(w, http.StatusCreated, grant)
+}
+
+func (api *CentralCognitoAPI) handleReadScopeGrant(w http.ResponseWriter, id string) {
+	grant, ok := api.ScopeGrants[id]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("scope grant %q not found", id), "NOT_FOUND")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, grant)
+}
+
+func (api *CentralCognitoAPI) handleDeleteScopeGrant(w http.ResponseWriter, id string) {
+	if _, ok := api.ScopeGrants[id]; !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("scope grant %q not found", id), "NOT_FOUND")
+		return
+	}
+	delete(api.ScopeGrants, id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (api *CentralCognitoAPI) handleImportResourceServer(w http.ResponseWriter, r *http.Request) {
+	var req central_cognito.ImportResourceServerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	rs, ok := api.ResourceServers[req.Identifier]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("resource server %q not found", req.Identifier), "NOT_FOUND")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, rs)
+}
+
+// respondWithPage lists the items sorted by their key, with the next_token being the offset of the next page.
+// page is one page of a listed collection, as the client reads it. NextToken is empty on the last page.
+type page[T any] struct {
+	Items     []T    `json:"items"`
+	NextToken string `json:"next_token"`
+}
+
+func respondWithPage[T any](w http.ResponseWriter, r *http.Request, pageSize int, items map[string]T) {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	offset := 0
+	if token := r.URL.Query().Get("next_token"); token != "" {
+		var err error
+		offset, err = strconv.Atoi(token)
+		if err != nil || offset < 0 || offset > len(items) {
+			respondWithError(w, http.StatusBadRequest, "invalid next_token", "BAD_REQUEST")
+			return
+		}
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	end := min(offset+pageSize, len(keys))
+	current := page[T]{Items: []T{}}
+	for _, key := range keys[offset:end] {
+		current.Items = append(current.Items, items[key])
+	}
+	if end < len(keys) {
+		current.NextToken = strconv.Itoa(end)
+	}
+
+	respondWithJSON(w, http.StatusOK, current)
+}
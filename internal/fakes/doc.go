@@ -0,0 +1,5 @@
+// Package fakes has in-memory fakes of the services the provider talks to.
+//
+// Only tests import this package, so the fakes are not built into the provider.
+// Call Start on a fake to get a running test server and a client of the service that talks to it.
+package fakes
@@ -0,0 +1,138 @@
+package fakes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+)
+
+// EnrollAccountAPI is an in-memory HTTP fake that emulates the account enrollment API.
+//
+// The real API enrolls the AWS account that signed the request.
+// The fake has no signatures to look at, so every request is treated as coming from CallerAccountId.
+type EnrollAccountAPI struct {
+	CallerAccountId    string
+	DeploymentAccount  *enroll_account.DeploymentAccount
+	EnvironmentAccount *enroll_account.EnvironmentAccount
+
+	mu sync.Mutex
+}
+
+func (api *EnrollAccountAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	switch strings.TrimPrefix(r.URL.Path, "/") {
+	case "accounts":
+		switch r.Method {
+		case http.MethodPost:
+			api.handleCreateDeploymentAccount(w, r)
+		case http.MethodGet:
+			api.handleReadDeploymentAccount(w)
+		case http.MethodDelete:
+			api.handleDeleteDeploymentAccount(w)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	case "environment_accounts":
+		switch r.Method {
+		case http.MethodPost:
+			api.handleRegisterEnvironmentAccount(w, r)
+		case http.MethodGet:
+			api.handleReadEnvironmentAccount(w)
+		case http.MethodDelete:
+			api.handleDeleteEnvironmentAccount(w)
+		default:
+			respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		}
+
+	default:
+		respondWithError(w, http.StatusNotFound, "unknown endpoint: "+r.URL.Path, "NOT_FOUND")
+	}
+}
+
+func (api *EnrollAccountAPI) handleCreateDeploymentAccount(w http.ResponseWriter, r *http.Request) {
+	var req enroll_account.CreateDeploymentAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	if req.SlackChannel == "" {
+		respondWithError(w, http.StatusBadRequest, "slack_channel is required", "BAD_REQUEST")
+		return
+	}
+
+	if api.DeploymentAccount != nil {
+		respondWithError(w, http.StatusConflict, "account "+api.CallerAccountId+" is already enrolled", "CONFLICT")
+		return
+	}
+
+	api.DeploymentAccount = &enroll_account.DeploymentAccount{
+		AccountId:    api.CallerAccountId,
+		SlackChannel: req.SlackChannel,
+	}
+	respondWithJSON(w, http.StatusCreated, api.DeploymentAccount)
+}
+
+func (api *EnrollAccountAPI) handleReadDeploymentAccount(w http.ResponseWriter) {
+	if api.DeploymentAccount == nil {
+		respondWithError(w, http.StatusNotFound, "account "+api.CallerAccountId+" is not enrolled", "NOT_FOUND")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, api.DeploymentAccount)
+}
+
+func (api *EnrollAccountAPI) handleDeleteDeploymentAccount(w http.ResponseWriter) {
+	if api.DeploymentAccount == nil {
+		respondWithError(w, http.StatusNotFound, "account "+api.CallerAccountId+" is not enrolled", "NOT_FOUND")
+		return
+	}
+	api.DeploymentAccount = nil
+	w.WriteHeader(http.StatusOK)
+}
+
+func (api *EnrollAccountAPI) handleRegisterEnvironmentAccount(w http.ResponseWriter, r *http.Request) {
+	var req enroll_account.EnvironmentAccountCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid request body: "+err.Error(), "BAD_REQUEST")
+		return
+	}
+
+	if req.OwnerAccountId == "" {
+		respondWithError(w, http.StatusBadRequest, "owner_account_id is required", "BAD_REQUEST")
+		return
+	}
+
+	if api.EnvironmentAccount != nil {
+		respondWithError(w, http.StatusConflict, "account "+api.CallerAccountId+" is already registered", "CONFLICT")
+		return
+	}
+
+	api.EnvironmentAccount = &enroll_account.EnvironmentAccount{
+		AccountId:      api.CallerAccountId,
+		OwnerAccountId: req.OwnerAccountId,
+	}
+	respondWithJSON(w, http.StatusCreated, api.EnvironmentAccount)
+}
+
+func (api *EnrollAccountAPI) handleReadEnvironmentAccount(w http.ResponseWriter) {
+	if api.EnvironmentAccount == nil {
+		respondWithError(w, http.StatusNotFound, "account "+api.CallerAccountId+" is not registered", "NOT_FOUND")
+		return
+	}
+	respondWithJSON(w, http.StatusOK, api.EnvironmentAccount)
+}
+
+func (api *EnrollAccountAPI) handleDeleteEnvironmentAccount(w http.ResponseWriter) {
+	if api.EnvironmentAccount == nil {
+		respondWithError(w, http.StatusNotFound, "account "+api.CallerAccountId+" is not registered", "NOT_FOUND")
+		return
+	}
+	api.EnvironmentAccount = nil
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,18 @@
+package fakes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+func respondWithJSON(w http.ResponseWriter, statusCode int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(payload)
+}
+
+func respondWithError(w http.ResponseWriter, statusCode int, message, errorType string) {
+	respondWithJSON(w, statusCode, api_errors.Payload{Message: message, ErrorType: errorType})
+}
@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler_v2"
+)
+
+// Start launches an httptest.Server running the fake API and returns it alongside
+// a Client pre-configured to talk to it. The caller must call server.Close() when done.
+func (api *CentralCognitoAPI) Start() (*httptest.Server, *central_cognito.Client) {
+	server := httptest.NewServer(api)
+	client := &central_cognito.Client{
+		BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
+		HTTPClient: server.Client(),
+	}
+	return server, client
+}
+
+// Start launches an httptest.Server running the fake API and returns it alongside
+// a Client pre-configured to talk to it. The caller must call server.Close() when done.
+func (api *EnrollAccountAPI) Start() (*httptest.Server, *enroll_account.Client) {
+	server := httptest.NewServer(api)
+	client := &enroll_account.Client{
+		BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
+		HTTPClient: server.Client(),
+	}
+	return server, client
+}
+
+// Start launches an httptest.Server running the fake API and returns it alongside
+// a Client pre-configured to talk to it. The caller must call server.Close() when done.
+func (api *VersionHandlerAPI) Start() (*httptest.Server, *version_handler.Client) {
+	server := httptest.NewServer(api)
+	client := &version_handler.Client{
+		BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
+		HTTPClient: server.Client(),
+	}
+	return server, client
+}
+
+// Start launches an httptest.Server running the fake API and returns it alongside
+// a Client pre-configured to talk to it. The caller must call server.Close() when done.
+func (api *VersionHandlerV2API) Start() (*httptest.Server, *version_handler_v2.Client) {
+	server := httptest.NewServer(api)
+	client := &version_handler_v2.Client{
+		BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
+		HTTPClient: server.Client(),
+	}
+	return server, client
+}
@@ -0,0 +1,43 @@
+package fakes
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
+)
+
+// VersionHandlerAPI is an in-memory HTTP fake that emulates the version-handler v1 API.
+// Populate it with known versions, then call Start() to get a running test server
+// and a pre-configured Client.
+type VersionHandlerAPI struct {
+	Versions map[string]version_handler.Version // application name → Version
+
+	mu sync.Mutex
+}
+
+func (api *VersionHandlerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	// Expected: /versions/{application_name}
+	segments := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(segments) != 2 || segments[0] != "versions" || segments[1] == "" {
+		respondWithError(w, http.StatusNotFound, "unknown endpoint: "+r.URL.Path, "NOT_FOUND")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED")
+		return
+	}
+
+	version, ok := api.Versions[segments[1]]
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "no version found for "+segments[1], "NOT_FOUND")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, version)
+}
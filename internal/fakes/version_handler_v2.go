@@ -1,21 +1,27 @@
-package version_handler_v2
+package fakes
 
 import (
-	"encoding/json"
 	"net/http"
-	"net/http/httptest"
 	"strings"
+	"sync"
+
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler_v2"
 )
 
-// FakeVersionHandlerAPI is an in-memory HTTP fake that emulates the version-handler v2 API.
+// VersionHandlerV2API is an in-memory HTTP fake that emulates the version-handler v2 API.
 // Populate it with known artifacts, then call Start() to get a running test server
 // and a pre-configured Client.
-type FakeVersionHandlerAPI struct {
-	KnownLambdaArtifacts []LambdaArtifact
-	KnownECSVersions     []ECSVersion
+type VersionHandlerV2API struct {
+	KnownLambdaArtifacts []version_handler_v2.LambdaArtifact
+	KnownECSVersions     []version_handler_v2.ECSVersion
+
+	mu sync.Mutex
 }
 
-func (api *FakeVersionHandlerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+func (api *VersionHandlerV2API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
 	// Expected: /v2/versions/{repository}/lambda or /v2/versions/{repository}/ecs
 	// Repository names may contain slashes (e.g. "nsbno/my-service"), so the path
 	// can have more than 4 segments. The artifact kind is always the last segment,
@@ -40,7 +46,7 @@ func (api *FakeVersionHandlerAPI) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-func (api *FakeVersionHandlerAPI) serveLambdaArtifact(w http.ResponseWriter, repositoryName string, queryParams map[string][]string) {
+func (api *VersionHandlerV2API) serveLambdaArtifact(w http.ResponseWriter, repositoryName string, queryParams map[string][]string) {
 	requestedECRName := firstQueryValue(queryParams, "ecr_repository_name")
 	requestedWorkDir := firstQueryValue(queryParams, "working_directory")
 	requestedPath := firstQueryValue(queryParams, "path")
@@ -65,7 +71,7 @@ func (api *FakeVersionHandlerAPI) serveLambdaArtifact(w http.ResponseWriter, rep
 	respondWithError(w, http.StatusNotFound, "artifact not found", "NOT_FOUND")
 }
 
-func (api *FakeVersionHandlerAPI) serveECSVersion(w http.ResponseWriter, repositoryName string, queryParams map[string][]string) {
+func (api *VersionHandlerV2API) serveECSVersion(w http.ResponseWriter, repositoryName string, queryParams map[string][]string) {
 	requestedECRName := firstQueryValue(queryParams, "ecr_repository_name")
 	requestedWorkDir := firstQueryValue(queryParams, "working_directory")
 
@@ -86,27 +92,6 @@ func (api *FakeVersionHandlerAPI) serveECSVersion(w http.ResponseWriter, reposit
 	respondWithError(w, http.StatusNotFound, "artifact not found", "NOT_FOUND")
 }
 
-// Start launches an httptest.Server running the fake API and returns it alongside
-// a Client pre-configured to talk to it. The caller must call server.Close() when done.
-func (api *FakeVersionHandlerAPI) Start() (*httptest.Server, *Client) {
-	server := httptest.NewServer(api)
-	client := &Client{
-		BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
-		HTTPClient: server.Client(),
-	}
-	return server, client
-}
-
-func respondWithJSON(w http.ResponseWriter, statusCode int, payload any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(payload)
-}
-
-func respondWithError(w http.ResponseWriter, statusCode int, message, errorType string) {
-	respondWithJSON(w, statusCode, apiErrorPayload{Message: message, ErrorType: errorType})
-}
-
 func firstQueryValue(queryParams map[string][]string, key string) string {
 	values, ok := queryParams[key]
 	if !ok || len(values) == 0 {
@@ -114,3 +99,10 @@ func firstQueryValue(queryParams map[string][]string, key string) string {
 	}
 	return values[0]
 }
+
+// normalizePath strips leading "./" and "/" prefixes from a directory path, the same way the client does.
+func normalizePath(p string) string {
+	p = strings.TrimPrefix(p, "./")
+	p = strings.TrimPrefix(p, "/")
+	return p
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &AppClientDataSource{}
+
+func NewAppClientDataSource() datasource.DataSource {
+	return &AppClientDataSource{}
+}
+
+type AppClientDataSource struct {
+	client *central_cognito.Client
+}
+
+// AppClientDataSourceModel is the app client without its secret, which is only for the owner of the app client.
+type AppClientDataSourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	ClientId       types.String `tfsdk:"client_id"`
+	Type           types.String `tfsdk:"type"`
+	Scopes         []string     `tfsdk:"scopes"`
+	CallbackUrls   []string     `tfsdk:"callback_urls"`
+	LogoutUrls     []string     `tfsdk:"logout_urls"`
+	GenerateSecret types.Bool   `tfsdk:"generate_secret"`
+}
+
+func (a AppClientDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_app_client"
+}
+
+func (a AppClientDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Get an app client owned by someone else, by its `name` or `client_id`. " +
+			"The secret of the app client is not available, use the `vy_app_client_secret` ephemeral resource if you own it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the app client. Either this or `client_id` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The ID the app client uses to authenticate itself. Either this or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The use-case for the app client. Either `frontend` or `backend`.",
+				Computed:            true,
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "Scopes that the app client has access to",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"callback_urls": schema.ListAttribute{
+				MarkdownDescription: "Callback URLs of the app client",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"logout_urls": schema.ListAttribute{
+				MarkdownDescription: "Logout URLs of the app client",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"generate_secret": schema.BoolAttribute{
+				MarkdownDescription: "Whether the app client has a secret",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (a *AppClientDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = configuration.CognitoClient
+}
+
+func (a AppClientDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state AppClientDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Name.IsNull() == state.ClientId.IsNull() {
+		response.Diagnostics.AddError(
+			"Invalid app client lookup",
+			"Set exactly one of `name` or `client_id` to find the app client.",
+		)
+		return
+	}
+
+	var appClient central_cognito.AppClient
+	var err error
+
+	attribute, lookup := "name", state.Name.ValueString()
+	if !state.ClientId.IsNull() {
+		attribute, lookup = "client_id", state.ClientId.ValueString()
+		err = a.client.ReadAppClientByClientId(ctx, lookup, &appClient)
+	} else {
+		err = a.client.ReadAppClient(ctx, lookup, &appClient)
+	}
+
+	if api_errors.IsNotFound(err) {
+		response.Diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"App client not found",
+			fmt.Sprintf("There is no app client with the %s %s.", attribute, lookup),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read app client",
+			fmt.Sprintf("Can't read app client %s from remote: %s", lookup, err.Error()),
+		)
+		return
+	}
+
+	state.Id = types.StringPointerValue(appClient.ClientId)
+	state.Name = types.StringValue(appClient.Name)
+	state.ClientId = types.StringPointerValue(appClient.ClientId)
+	state.Type = types.StringValue(appClient.Type)
+	state.Scopes = appClient.Scopes
+	state.CallbackUrls = appClient.CallbackUrls
+	state.LogoutUrls = appClient.LogoutUrls
+	state.GenerateSecret = types.BoolPointerValue(appClient.GenerateSecret)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeAppClientDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+
+	clientId := "other-team-client-id"
+	clientSecret := "other-team-secret"
+	generateSecret := true
+	apis.Cognito.AppClients["other-team-backend"] = central_cognito.AppClient{
+		Name:           "other-team-backend",
+		Type:           "backend",
+		Scopes:         []string{"other-team.fake.io/read"},
+		GenerateSecret: &generateSecret,
+		ClientId:       &clientId,
+		ClientSecret:   &clientSecret,
+	}
+	expected_resource_name := "data.vy_app_client.this"
+
+	check := resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr(expected_resource_name, "id", "other-team-client-id"),
+		resource.TestCheckResourceAttr(expected_resource_name, "name", "other-team-backend"),
+		resource.TestCheckResourceAttr(expected_resource_name, "client_id", "other-team-client-id"),
+		resource.TestCheckResourceAttr(expected_resource_name, "type", "backend"),
+		resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "1"),
+		resource.TestCheckResourceAttr(expected_resource_name, "generate_secret", "true"),
+		resource.TestCheckNoResourceAttr(expected_resource_name, "client_secret"),
+	)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_client" "this" {
+	name = "other-team-backend"
+}
+`,
+				Check: check,
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_client" "this" {
+	client_id = "other-team-client-id"
+}
+`,
+				Check: check,
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_client" "this" {
+	name = "does-not-exist"
+}
+`,
+				ExpectError: regexp.MustCompile("App client not found"),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_client" "this" {
+	name      = "other-team-backend"
+	client_id = "other-team-client-id"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid app client lookup"),
+			},
+		},
+	})
+}
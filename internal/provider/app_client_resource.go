@@ -3,55 +3,48 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ validator.String = frontendOrBackendValidator{}
-
-type frontendOrBackendValidator struct{}
-
-func (t frontendOrBackendValidator) Description(ctx context.Context) string {
-	return "type must be either 'frontend' or 'backend'"
-}
-
-func (t frontendOrBackendValidator) MarkdownDescription(ctx context.Context) string {
-	return "type must be either `frontend` or `backend`"
-}
-
-func (t frontendOrBackendValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
-	var str = request.ConfigValue
-
-	if str.IsUnknown() || str.IsNull() {
-		return
-	}
-
-	if str.ValueString() != "frontend" && str.ValueString() != "backend" {
-		response.Diagnostics.AddAttributeError(
-			request.Path,
-			"Invalid app client type",
-			fmt.Sprintf("The app client must either be 'frontend' or 'backend'. Got: '%s'.", str.ValueString()),
-		)
-
-		return
-	}
-}
+// defaultSecretRotationOverlap is how long the previous secret stays valid after a rotation, unless configured.
+const defaultSecretRotationOverlap = "24h"
 
 func NewAppClientResource() resource.Resource {
 	return &AppClientResource{}
 }
 
+var _ resource.ResourceWithModifyPlan = &AppClientResource{}
+var _ resource.ResourceWithConfigValidators = &AppClientResource{}
+var _ resource.ResourceWithUpgradeState = &AppClientResource{}
+
 type AppClientResource struct {
 	client *central_cognito.Client
+	// environment is the `environment` of the provider, like `prod`. Empty until the provider is configured.
+	environment string
+	userPools   map[string]userPool
+	// plannedScopes are the scopes that the resource servers and scopes in the configuration create.
+	plannedScopes *plannedScopes
 }
 
 type AppClientResourceModel struct {
@@ -64,6 +57,22 @@ type AppClientResourceModel struct {
 	GenerateSecret types.Bool   `tfsdk:"generate_secret"`
 	ClientId       types.String `tfsdk:"client_id"`
 	ClientSecret   types.String `tfsdk:"client_secret"`
+
+	SecretRotationTrigger types.String `tfsdk:"secret_rotation_trigger"`
+	RotateAfterDays       types.Int64  `tfsdk:"rotate_after_days"`
+	SecretRotationOverlap types.String `tfsdk:"secret_rotation_overlap"`
+	ClientSecretCreatedAt types.String `tfsdk:"client_secret_created_at"`
+
+	AccessTokenValidityMinutes types.Int64  `tfsdk:"access_token_validity_minutes"`
+	IdTokenValidityMinutes     types.Int64  `tfsdk:"id_token_validity_minutes"`
+	RefreshTokenValidityDays   types.Int64  `tfsdk:"refresh_token_validity_days"`
+	AllowedOAuthFlows          types.Set    `tfsdk:"allowed_oauth_flows"`
+	PreventUserExistenceErrors types.Bool   `tfsdk:"prevent_user_existence_errors"`
+	EnableTokenRevocation      types.Bool   `tfsdk:"enable_token_revocation"`
+	DefaultRedirectUri         types.String `tfsdk:"default_redirect_uri"`
+	SupportedIdentityProviders types.Set    `tfsdk:"supported_identity_providers"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AppClientResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -72,27 +81,33 @@ func (r *AppClientResource) Metadata(ctx context.Context, request resource.Metad
 
 func (r *AppClientResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
+		// Version 1 uses the client_id as id, instead of the name.
+		Version: 1,
+
 		MarkdownDescription: "App clients are the user pool authentication resources attached to your app. " +
 			"Use an app client to configure the permitted authentication actions towards a resource server.",
 
 		Attributes: map[string]schema.Attribute{
 			// id is required by the SDKv2 testing framework.
 			// See https://www.terraform.io/plugin/framework/acctests#implement-id-attribute
-			// TODO: Later, this is probably going to be the generated ID from cognito.
 			"id": schema.StringAttribute{
-				Computed: true,
+				MarkdownDescription: "The `client_id` of this app client. Unlike the name, it never changes.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of this app client",
+				MarkdownDescription: "The name of this app client. Changing it renames the client in place, keeping its `client_id` and `client_secret`.",
 				Required:            true,
 			},
 			"scopes": schema.SetAttribute{
-				MarkdownDescription: "Scopes that this client has access to",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Scopes that this client has access to. " +
+					"Scopes are checked when planning, and unknown scopes are reported with the nearest valid scopes. " +
+					"Scopes that are created in the same apply must be planned first, by referencing their `vy_resource_server` or `vy_resource_server_scope`. " +
+					"Scopes of resource servers that don't exist yet only give a warning.",
+				Optional:    true,
+				ElementType: types.StringType,
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "The use-case for this app client. Used to automatically add OAuth options. " +
@@ -106,14 +121,22 @@ func (r *AppClientResource) Schema(ctx context.Context, request resource.SchemaR
 				},
 			},
 			"callback_urls": schema.ListAttribute{
-				MarkdownDescription: "Callback URLs to use. Used together with `type` set to `frontend`.",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Callback URLs to use. Only allowed with `type` set to `frontend`. " +
+					"Must use HTTPS, except for `localhost`, which is not allowed in `prod`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					urlsValidator{},
+				},
 			},
 			"logout_urls": schema.ListAttribute{
-				MarkdownDescription: "Logout URLs to use. Used together with `type` set to `frontend`.",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "Logout URLs to use. Only allowed with `type` set to `frontend`. " +
+					"Must use HTTPS, except for `localhost`, which is not allowed in `prod`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					urlsValidator{},
+				},
 			},
 			"generate_secret": schema.BoolAttribute{
 				MarkdownDescription: "Should a secret be generated? Automatically set by `type`, but you're able to override it with this option.",
@@ -133,13 +156,133 @@ func (r *AppClientResource) Schema(ctx context.Context, request resource.SchemaR
 			},
 			"client_secret": schema.StringAttribute{
 				MarkdownDescription: "A secret used for your client to authenticate itself. " +
-					"Only populated when using the `backend` type.",
+					"Only populated when using the `backend` type. " +
+					"Use the `vy_app_client_secret` ephemeral resource to read it without keeping it in state.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_rotation_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value that rotates `client_secret` when it changes, e.g. a date. " +
+					"Setting it for the first time, or removing it, does not rotate the secret. " +
+					"The client keeps its `client_id`, so consumers only need the new secret.",
+				Optional: true,
+			},
+			"rotate_after_days": schema.Int64Attribute{
+				MarkdownDescription: "Rotate `client_secret` on the first apply after it has reached this age in days.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					atLeastOneValidator{},
+				},
+			},
+			"secret_rotation_overlap": schema.StringAttribute{
+				MarkdownDescription: "How long the previous secret stays valid after a rotation, as a duration like `24h`. " +
+					"Defaults to `" + defaultSecretRotationOverlap + "`.",
+				Optional: true,
 				Computed: true,
+				Default:  stringdefault.StaticString(defaultSecretRotationOverlap),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"client_secret_created_at": schema.StringAttribute{
+				MarkdownDescription: "When the current `client_secret` was created, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// The settings below are derived from `type` by the server when they are not set.
+			"access_token_validity_minutes": schema.Int64Attribute{
+				MarkdownDescription: "How many minutes access tokens are valid for. Set by the server when left out.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					atLeastOneValidator{},
+				},
+			},
+			"id_token_validity_minutes": schema.Int64Attribute{
+				MarkdownDescription: "How many minutes ID tokens are valid for. Set by the server when left out.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					atLeastOneValidator{},
+				},
+			},
+			"refresh_token_validity_days": schema.Int64Attribute{
+				MarkdownDescription: "How many days refresh tokens are valid for. Set by the server when left out.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					atLeastOneValidator{},
+				},
+			},
+			"allowed_oauth_flows": schema.SetAttribute{
+				MarkdownDescription: "The OAuth flows the client may use: `code`, `implicit` and `client_credentials`. " +
+					"Derived from `type` when left out.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					oauthFlowsValidator{},
+				},
+			},
+			"prevent_user_existence_errors": schema.BoolAttribute{
+				MarkdownDescription: "Hide whether a user exists when sign in fails. Set by the server when left out.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enable_token_revocation": schema.BoolAttribute{
+				MarkdownDescription: "Allow refresh tokens of the client to be revoked. Set by the server when left out.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_redirect_uri": schema.StringAttribute{
+				MarkdownDescription: "The redirect URI used when a sign in request has none. Must be one of `callback_urls`.",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
+				Validators: []validator.String{
+					urlValidator{},
+				},
+			},
+			"supported_identity_providers": schema.SetAttribute{
+				MarkdownDescription: "The identity providers users can sign in with on the hosted login page, e.g. `COGNITO`. " +
+					"See the `vy_identity_providers` data source for the available providers. " +
+					"Defaults to `COGNITO` for `frontend` clients.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, updatableResourceTimeouts),
+		},
 	}
 }
 
@@ -161,6 +304,9 @@ func (c *AppClientResource) Configure(ctx context.Context, request resource.Conf
 	}
 
 	c.client = configuration.CognitoClient
+	c.environment = configuration.Environment
+	c.userPools = configuration.userPools
+	c.plannedScopes = configuration.plannedScopes
 }
 
 func (ac AppClientResourceModel) toDomain(domain *central_cognito.AppClient) {
@@ -185,10 +331,64 @@ func (ac AppClientResourceModel) toDomain(domain *central_cognito.AppClient) {
 		value := ac.GenerateSecret.ValueBool()
 		domain.GenerateSecret = &value
 	}
+
+	// Settings that are null or unknown are left out, so the server keeps or derives them.
+	if !ac.AccessTokenValidityMinutes.IsUnknown() {
+		domain.AccessTokenValidityMinutes = ac.AccessTokenValidityMinutes.ValueInt64Pointer()
+	}
+	if !ac.IdTokenValidityMinutes.IsUnknown() {
+		domain.IdTokenValidityMinutes = ac.IdTokenValidityMinutes.ValueInt64Pointer()
+	}
+	if !ac.RefreshTokenValidityDays.IsUnknown() {
+		domain.RefreshTokenValidityDays = ac.RefreshTokenValidityDays.ValueInt64Pointer()
+	}
+	if !ac.PreventUserExistenceErrors.IsUnknown() {
+		domain.PreventUserExistenceErrors = ac.PreventUserExistenceErrors.ValueBoolPointer()
+	}
+	if !ac.EnableTokenRevocation.IsUnknown() {
+		domain.EnableTokenRevocation = ac.EnableTokenRevocation.ValueBoolPointer()
+	}
+	if !ac.DefaultRedirectUri.IsUnknown() {
+		domain.DefaultRedirectUri = ac.DefaultRedirectUri.ValueStringPointer()
+	}
+
+	domain.AllowedOAuthFlows = stringSetToSlice(ac.AllowedOAuthFlows)
+	domain.SupportedIdentityProviders = stringSetToSlice(ac.SupportedIdentityProviders)
+}
+
+// stringSetToSlice converts a set of strings for the API. Null and unknown sets become nil, so they are left out,
+// while an empty set is sent as an empty list.
+func stringSetToSlice(set types.Set) *[]string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	values := []string{}
+	for _, element := range set.Elements() {
+		if value, ok := element.(types.String); ok {
+			values = append(values, value.ValueString())
+		}
+	}
+
+	return &values
+}
+
+// stringSetFromSlice is the opposite of stringSetToSlice. A nil slice becomes a null set.
+func stringSetFromSlice(values *[]string) types.Set {
+	if values == nil {
+		return types.SetNull(types.StringType)
+	}
+
+	elements := []attr.Value{}
+	for _, value := range *values {
+		elements = append(elements, types.StringValue(value))
+	}
+
+	return types.SetValueMust(types.StringType, elements)
 }
 
 func appClientResourceDataFromDomain(domain central_cognito.AppClient, state *AppClientResourceModel) {
-	state.Id = types.StringValue(domain.Name)
+	state.Id = types.StringPointerValue(domain.ClientId)
 	state.Name = types.StringValue(domain.Name)
 	state.Scopes = domain.Scopes
 	state.Type = types.StringValue(domain.Type)
@@ -218,6 +418,52 @@ func appClientResourceDataFromDomain(domain central_cognito.AppClient, state *Ap
 	if domain.ClientSecret != nil {
 		state.ClientSecret = types.StringValue(*domain.ClientSecret)
 	}
+
+	state.ClientSecretCreatedAt = types.StringPointerValue(domain.ClientSecretCreatedAt)
+
+	state.AccessTokenValidityMinutes = types.Int64PointerValue(domain.AccessTokenValidityMinutes)
+	state.IdTokenValidityMinutes = types.Int64PointerValue(domain.IdTokenValidityMinutes)
+	state.RefreshTokenValidityDays = types.Int64PointerValue(domain.RefreshTokenValidityDays)
+	state.PreventUserExistenceErrors = types.BoolPointerValue(domain.PreventUserExistenceErrors)
+	state.EnableTokenRevocation = types.BoolPointerValue(domain.EnableTokenRevocation)
+	state.DefaultRedirectUri = types.StringPointerValue(domain.DefaultRedirectUri)
+
+	state.AllowedOAuthFlows = stringSetFromSlice(domain.AllowedOAuthFlows)
+	state.SupportedIdentityProviders = stringSetFromSlice(domain.SupportedIdentityProviders)
+}
+
+// withRotationSettings copies the rotation settings, which only exist in the configuration, to the new state.
+func (ac *AppClientResourceModel) withRotationSettings(from AppClientResourceModel) {
+	ac.SecretRotationTrigger = from.SecretRotationTrigger
+	ac.RotateAfterDays = from.RotateAfterDays
+	ac.SecretRotationOverlap = from.SecretRotationOverlap
+}
+
+// secretRotationTriggered tells if `secret_rotation_trigger` changed from the prior value to the planned one.
+// Setting the trigger for the first time, or removing it, doesn't rotate the secret.
+// A planned value that is unknown, like the id of a `time_rotating`, counts as a change.
+func secretRotationTriggered(prior types.String, planned types.String) bool {
+	if prior.IsNull() || planned.IsNull() {
+		return false
+	}
+
+	return !planned.Equal(prior)
+}
+
+// secretRotationDue tells if the secret has reached the age set by `rotate_after_days`.
+func (ac AppClientResourceModel) secretRotationDue(now time.Time) bool {
+	if ac.RotateAfterDays.IsNull() || ac.RotateAfterDays.IsUnknown() || ac.ClientSecretCreatedAt.IsNull() {
+		return false
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, ac.ClientSecretCreatedAt.ValueString())
+	if err != nil {
+		return false
+	}
+
+	maxAge := time.Duration(ac.RotateAfterDays.ValueInt64()) * 24 * time.Hour
+
+	return !createdAt.Add(maxAge).After(now)
 }
 
 func (r AppClientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -230,12 +476,19 @@ func (r AppClientResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	data.Id = data.Name
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	var appClient central_cognito.AppClient
 	data.toDomain(&appClient)
 
-	var createdAppClient, err = r.client.CreateAppClient(appClient)
+	var createdAppClient, err = r.client.CreateAppClient(ctx, appClient)
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -247,8 +500,13 @@ func (r AppClientResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	var createdAppClientResource AppClientResourceModel
+	// The configuration doesn't have the default overlap, so the settings are taken from the plan.
+	var plan AppClientResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	createdAppClientResource := AppClientResourceModel{Timeouts: data.Timeouts}
 	appClientResourceDataFromDomain(*createdAppClient, &createdAppClientResource)
+	createdAppClientResource.withRotationSettings(plan)
 
 	diags = resp.State.Set(ctx, &createdAppClientResource)
 	resp.Diagnostics.Append(diags...)
@@ -264,8 +522,26 @@ func (r AppClientResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// The app client is read by its client_id, so changes to the name outside of Terraform are detected.
 	var server central_cognito.AppClient
-	err := r.client.ReadAppClient(data.Name.ValueString(), &server)
+	err := r.client.ReadAppClientByClientId(ctx, data.Id.ValueString(), &server)
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "App client no longer exists in remote, removing it from state", map[string]interface{}{
+			"name":      data.Name.ValueString(),
+			"client_id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -276,8 +552,9 @@ func (r AppClientResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	var newState AppClientResourceModel
+	newState := AppClientResourceModel{Timeouts: data.Timeouts}
 	appClientResourceDataFromDomain(server, &newState)
+	newState.withRotationSettings(data)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
@@ -297,21 +574,59 @@ func (r AppClientResource) Update(ctx context.Context, req resource.UpdateReques
 	if data.ClientSecret.IsUnknown() && state.ClientSecret.IsNull() {
 		data.ClientSecret = state.ClientSecret
 	}
+	if data.ClientSecretCreatedAt.IsUnknown() && state.ClientSecretCreatedAt.IsNull() {
+		data.ClientSecretCreatedAt = state.ClientSecretCreatedAt
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The trigger is compared again, since it can be unknown when planning.
+	// The age of the secret is only checked when planning, so ModifyPlan marks it as unknown when it is due.
+	triggered := secretRotationTriggered(state.SecretRotationTrigger, data.SecretRotationTrigger)
+	due := data.ClientSecretCreatedAt.IsUnknown()
+	rotate := !state.ClientSecret.IsNull() && (triggered || due)
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.Id = data.Name
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	var appClient central_cognito.AppClient
 	data.toDomain(&appClient)
 
-	err := r.client.UpdateAppClient(central_cognito.AppClientUpdateRequest{
-		Name:         appClient.Name,
-		Scopes:       appClient.Scopes,
-		CallbackUrls: appClient.CallbackUrls,
-		LogoutUrls:   appClient.LogoutUrls,
+	// The name is the key in the API, so the client is renamed before anything else is changed.
+	if !data.Name.Equal(state.Name) {
+		_, err := r.client.RenameAppClient(ctx, state.Name.ValueString(), appClient.Name)
+		if err != nil {
+			diags = diag.Diagnostics{}
+			diags.AddError(
+				"Unable to rename app client",
+				fmt.Sprintf("Can't rename app client %s to %s in remote: %s ", state.Name.ValueString(), data.Name.ValueString(), err.Error()),
+			)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	updatedAppClient, err := r.client.UpdateAppClient(ctx, central_cognito.AppClientUpdateRequest{
+		Name:                       appClient.Name,
+		Scopes:                     appClient.Scopes,
+		CallbackUrls:               appClient.CallbackUrls,
+		LogoutUrls:                 appClient.LogoutUrls,
+		AccessTokenValidityMinutes: appClient.AccessTokenValidityMinutes,
+		IdTokenValidityMinutes:     appClient.IdTokenValidityMinutes,
+		RefreshTokenValidityDays:   appClient.RefreshTokenValidityDays,
+		AllowedOAuthFlows:          appClient.AllowedOAuthFlows,
+		PreventUserExistenceErrors: appClient.PreventUserExistenceErrors,
+		EnableTokenRevocation:      appClient.EnableTokenRevocation,
+		DefaultRedirectUri:         appClient.DefaultRedirectUri,
+		SupportedIdentityProviders: appClient.SupportedIdentityProviders,
 	})
 	if err != nil {
 		diags = diag.Diagnostics{}
@@ -323,10 +638,188 @@ func (r AppClientResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	diags = resp.State.Set(ctx, &data)
+	updatedAppClientResource := AppClientResourceModel{Timeouts: data.Timeouts}
+	appClientResourceDataFromDomain(*updatedAppClient, &updatedAppClientResource)
+	updatedAppClientResource.withRotationSettings(data)
+
+	if rotate {
+		overlap, err := time.ParseDuration(data.SecretRotationOverlap.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret_rotation_overlap"),
+				"Invalid duration",
+				fmt.Sprintf("Can't parse the secret rotation overlap: %s", err.Error()),
+			)
+			return
+		}
+
+		rotation, err := r.client.RotateAppClientSecret(ctx, appClient.Name, central_cognito.RotateAppClientSecretRequest{
+			OverlapSeconds: int64(overlap.Seconds()),
+		})
+		if err != nil {
+			diags = diag.Diagnostics{}
+			diags.AddError(
+				"Unable to rotate app client secret",
+				fmt.Sprintf("Can't rotate the secret of app client %s in remote: %s ", data.Name.ValueString(), err.Error()),
+			)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		updatedAppClientResource.ClientSecret = types.StringValue(rotation.ClientSecret)
+		updatedAppClientResource.ClientSecretCreatedAt = types.StringValue(rotation.ClientSecretCreatedAt)
+	}
+
+	diags = resp.State.Set(ctx, &updatedAppClientResource)
 	resp.Diagnostics.Append(diags...)
 }
 
+// ConfigValidators checks the URLs against the type of the app client, and the environment of the provider.
+func (r AppClientResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		frontendUrlsValidator{},
+		defaultRedirectUriValidator{},
+		localhostUrlsValidator{environment: r.environment},
+	}
+}
+
+// ModifyPlan checks that the scopes exist, and plans a new secret when `secret_rotation_trigger` changes,
+// or when the secret is older than `rotate_after_days`.
+func (r AppClientResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the app client is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.validateScopes(ctx, req, resp)
+
+	// Nothing to rotate when the app client is created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// Only the rotation settings are read from the plan, as other attributes, like the scopes, can be unknown.
+	var plan AppClientResourceModel
+	var state AppClientResourceModel
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("secret_rotation_trigger"), &plan.SecretRotationTrigger)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotate_after_days"), &plan.RotateAfterDays)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("client_secret_created_at"), &plan.ClientSecretCreatedAt)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	triggered := secretRotationTriggered(state.SecretRotationTrigger, plan.SecretRotationTrigger)
+	// The plan has the configured age, and the creation time of the current secret from the state.
+	due := plan.secretRotationDue(time.Now())
+
+	if !triggered && !due {
+		return
+	}
+
+	if state.ClientSecret.IsNull() {
+		if triggered {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret_rotation_trigger"),
+				"App client has no secret",
+				fmt.Sprintf("The app client %s was created without a secret, so there is nothing to rotate.", state.Name.ValueString()),
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret_created_at"), types.StringUnknown())...)
+}
+
+// validateScopes catches typos in the scopes before the apply, while suggesting the nearest scopes that exist.
+//
+// Scopes that don't exist are errors, unless a resource server or scope in the configuration creates them.
+// Those are only seen when the app client references them, or depends on them, so they are planned first.
+// Scopes of resource servers that don't exist only give a warning, since they can be created elsewhere in the same apply.
+func (r AppClientResource) validateScopes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var planned types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("scopes"), &planned)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+
+	// Scopes that are already applied have been accepted by the remote, so they are only checked when they change.
+	if !req.State.Raw.IsNull() {
+		var current types.Set
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("scopes"), &current)...)
+		if resp.Diagnostics.HasError() || planned.Equal(current) {
+			return
+		}
+	}
+
+	servers, err := r.client.ListResourceServers(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("scopes"),
+			"Unable to validate scopes",
+			fmt.Sprintf("Can't list resource servers from remote, so the scopes are only checked during the apply: %s", err.Error()),
+		)
+		return
+	}
+
+	info, err := readCognitoInfo(ctx, r.client, r.environment, r.userPools)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("scopes"),
+			"Unable to validate scopes",
+			fmt.Sprintf("Can't read the scopes that the user pool supports, so the scopes are only checked during the apply: %s", err.Error()),
+		)
+		return
+	}
+
+	available := availableScopes(servers, info.ScopesSupported)
+
+	var unknownScopes []string
+	var missingServers []string
+	for _, element := range planned.Elements() {
+		scope, ok := element.(types.String)
+		if !ok || scope.IsNull() || scope.IsUnknown() || slices.Contains(available, scope.ValueString()) || r.plannedScopes.contains(scope.ValueString()) {
+			continue
+		}
+
+		line := fmt.Sprintf("  - %s", scope.ValueString())
+		if nearest := nearestScopes(scope.ValueString(), available); len(nearest) > 0 {
+			line += fmt.Sprintf(" (did you mean %s?)", strings.Join(nearest, ", "))
+		}
+
+		// Scopes without an identifier can only be standard scopes, which no resource server creates.
+		_, found := resourceServerOf(scope.ValueString(), servers)
+		if found || !strings.Contains(scope.ValueString(), "/") {
+			unknownScopes = append(unknownScopes, line)
+		} else {
+			missingServers = append(missingServers, line)
+		}
+	}
+
+	if len(unknownScopes) > 0 {
+		slices.Sort(unknownScopes)
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scopes"),
+			"Unknown scopes",
+			fmt.Sprintf("These scopes don't exist in the shared Cognito:\n%s\n\n"+
+				"Scopes that are created in the same apply must be planned before the app client. "+
+				"Reference their `vy_resource_server_scope` or `vy_resource_server`, or add it to `depends_on`.", strings.Join(unknownScopes, "\n")),
+		)
+	}
+
+	if len(missingServers) > 0 {
+		slices.Sort(missingServers)
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("scopes"),
+			"Unknown resource servers",
+			fmt.Sprintf("These scopes belong to resource servers that don't exist yet. "+
+				"The apply fails unless the resource servers are created first:\n%s", strings.Join(missingServers, "\n")),
+		)
+	}
+}
+
 func (r AppClientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data AppClientResourceModel
 
@@ -337,7 +830,16 @@ func (r AppClientResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteAppClient(data.Name.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteAppClient(ctx, data.Name.ValueString())
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -351,31 +853,114 @@ func (r AppClientResource) Delete(ctx context.Context, req resource.DeleteReques
 	resp.State.RemoveResource(ctx)
 }
 
+// UpgradeState moves states from before version 1, where the id was the name of the app client.
+func (r AppClientResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	// Only the meaning of id changed, so the prior schema is the current one.
+	// Attributes that were added later are missing from older states, and are read as null.
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	priorSchema := current.Schema
+	priorSchema.Version = 0
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data AppClientResourceModel
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				if data.ClientId.IsNull() {
+					resp.Diagnostics.AddError(
+						"Unable to upgrade app client state",
+						fmt.Sprintf("The state of app client %s has no client_id. Remove it from the state and import it again.", data.Name.ValueString()),
+					)
+					return
+				}
+
+				data.Id = data.ClientId
+				if data.SecretRotationOverlap.IsNull() {
+					data.SecretRotationOverlap = types.StringValue(defaultSecretRotationOverlap)
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 // ImportState imports an existing app client into the state.
-// If it doesn't find a app client in the system, it will try to import from old delegated cognito.
 //
-// Because the new system uses names as its primary key, it is dual function.
-// To import an existing app client, use the name of the app client.
-// To import from the old system, the `client_id` must be used.
+// The app client is found by the prefix of the ID:
+// `name:<name>` reads it by its name, and `client_id:<client_id>` by its client_id.
+// App clients that only exist in the old delegated Cognito are imported by their `client_id`.
+//
+// IDs without a prefix are still supported, but deprecated. They are tried as a name first, then as a client_id.
 func (r AppClientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var importedAppClient central_cognito.AppClient
+	var err error
+	// searched tells where the app client was looked for, as only client_ids are looked up in the old delegated Cognito.
+	searched := "the delegated Cognito"
+
+	kind, value, found := strings.Cut(req.ID, ":")
+	switch {
+	case found && kind == "name":
+		err = r.client.ReadAppClient(ctx, value, &importedAppClient)
+	case found && kind == "client_id":
+		err = r.readAppClientByClientId(ctx, value, &importedAppClient)
+		searched = "the new or old delegated Cognito"
+	case !found:
+		resp.Diagnostics.AddWarning(
+			"Deprecated import ID",
+			fmt.Sprintf("Import app clients with `name:%s` or `client_id:%s`. IDs without a prefix will stop working in a later version.", req.ID, req.ID),
+		)
 
-	err := r.client.ReadAppClient(req.ID, &importedAppClient)
+		err = r.client.ReadAppClient(ctx, req.ID, &importedAppClient)
+		if api_errors.IsNotFound(err) {
+			err = r.readAppClientByClientId(ctx, req.ID, &importedAppClient)
+			searched = "the new or old delegated Cognito"
+		}
+	default:
+		resp.Diagnostics.AddError(
+			"Unexpected import ID",
+			fmt.Sprintf("Expected `name:<name>` or `client_id:<client_id>`. Got: %s", req.ID),
+		)
+		return
+	}
 
+	if api_errors.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Unable to import app client",
+			fmt.Sprintf("The app client could not be found in %s.\nUnderlying error: %s", searched, err),
+		)
+		return
+	}
 	if err != nil {
-		err = r.client.ImportAppClient(req.ID, &importedAppClient)
-
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to import app client",
-				fmt.Sprintf("The app client could not be found in the new or old delegated Cognito.\nUnderlying error: %s", err),
-			)
-			return
-		}
+		resp.Diagnostics.AddError(
+			"Unable to import app client",
+			fmt.Sprintf("Can't read app client %s from remote: %s ", req.ID, err.Error()),
+		)
+		return
 	}
 
-	var appClientData AppClientResourceModel
+	appClientData := AppClientResourceModel{
+		SecretRotationOverlap: types.StringValue(defaultSecretRotationOverlap),
+		Timeouts:              nullTimeouts(updatableResourceTimeouts),
+	}
 	appClientResourceDataFromDomain(importedAppClient, &appClientData)
 
 	resp.State.Set(ctx, &appClientData)
 }
+
+// readAppClientByClientId reads an app client by its client_id,
+// falling back to the old delegated Cognito if the app client is not in the new one.
+func (r AppClientResource) readAppClientByClientId(ctx context.Context, clientId string, appClient *central_cognito.AppClient) error {
+	err := r.client.ReadAppClientByClientId(ctx, clientId, appClient)
+	if api_errors.IsNotFound(err) {
+		err = r.client.ImportAppClient(ctx, clientId, appClient)
+	}
+	return err
+}
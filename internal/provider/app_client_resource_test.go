@@ -1,9 +1,28 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
 )
 
 const testAccAppClient_ResourceServer = `
@@ -167,3 +186,919 @@ func TestAccAppClient_Complex(t *testing.T) {
 		},
 	})
 }
+
+const testFakeAppClient_Create = testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-backend"
+	type = "backend"
+	scopes = [
+		"https://fake.vydev.io/demo/read",
+		"https://fake.vydev.io/demo/modify",
+	]
+}
+`
+
+const testFakeAppClient_Update = testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-backend"
+	type = "backend"
+	scopes = [
+		"https://fake.vydev.io/demo/read",
+	]
+}
+`
+
+func TestFakeAppClient_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAppClient_Create,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "client_id", "generated-client-id-fake-backend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "client_secret", "generated-secret-for-fake-backend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "generate_secret", "true"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "2"),
+				),
+			},
+			{
+				Config: testFakeAppClient_Update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "client_id", "generated-client-id-fake-backend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "1"),
+				),
+			},
+			{
+				ResourceName:      expected_resource_name,
+				ImportState:       true,
+				ImportStateId:     "name:fake-backend",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      expected_resource_name,
+				ImportState:       true,
+				ImportStateId:     "client_id:generated-client-id-fake-backend",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  expected_resource_name,
+				ImportState:   true,
+				ImportStateId: "arn:fake-backend",
+				ExpectError:   regexp.MustCompile("Unexpected import ID"),
+			},
+			{
+				// The app client is deleted outside of Terraform, so it should be planned to be created again.
+				PreConfig: func() {
+					if err := apis.CognitoClient.DeleteAppClient(context.Background(), "fake-backend"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeAppClient_Update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testFakeAppClient_WithRotationTrigger(trigger string) string {
+	return testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-backend"
+	type = "backend"
+	secret_rotation_trigger = "` + trigger + `"
+	secret_rotation_overlap = "1h"
+}
+`
+}
+
+func TestFakeAppClient_RotateSecret(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAppClient_WithRotationTrigger("2026-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "client_secret", "generated-secret-for-fake-backend"),
+					resource.TestCheckResourceAttrSet(expected_resource_name, "client_secret_created_at"),
+				),
+			},
+			{
+				Config: testFakeAppClient_WithRotationTrigger("2026-02"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(expected_resource_name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "client_id", "generated-client-id-fake-backend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "client_secret", "rotated-secret-1-for-fake-backend"),
+					resource.TestCheckResourceAttrSet(expected_resource_name, "client_secret_created_at"),
+				),
+			},
+			{
+				// The secret is only rotated when the trigger changes.
+				Config:   testFakeAppClient_WithRotationTrigger("2026-02"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testFakeAppClient_WithTokenSettings(flows string, idTokenValidity string) string {
+	return testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-frontend"
+	type = "frontend"
+	callback_urls = ["https://fake.vydev.io/callback"]
+
+	access_token_validity_minutes = 15
+	id_token_validity_minutes = ` + idTokenValidity + `
+	allowed_oauth_flows = ` + flows + `
+	default_redirect_uri = "https://fake.vydev.io/callback"
+}
+`
+}
+
+func TestFakeAppClient_TokenSettings(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAppClient_WithTokenSettings(`["code"]`, "60"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "access_token_validity_minutes", "15"),
+					resource.TestCheckResourceAttr(expected_resource_name, "default_redirect_uri", "https://fake.vydev.io/callback"),
+					// Settings that are left out are set by the server.
+					resource.TestCheckResourceAttr(expected_resource_name, "refresh_token_validity_days", "30"),
+					resource.TestCheckResourceAttr(expected_resource_name, "prevent_user_existence_errors", "true"),
+					resource.TestCheckResourceAttr(expected_resource_name, "enable_token_revocation", "true"),
+				),
+			},
+			{
+				Config: testFakeAppClient_WithTokenSettings(`["code", "implicit"]`, "30"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(expected_resource_name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "client_id", "generated-client-id-fake-frontend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "allowed_oauth_flows.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "id_token_validity_minutes", "30"),
+					resource.TestCheckResourceAttr(expected_resource_name, "refresh_token_validity_days", "30"),
+				),
+			},
+			{
+				// A setting that is left out can change outside of Terraform without a diff.
+				PreConfig: func() {
+					days := int64(7)
+					_, err := apis.CognitoClient.UpdateAppClient(context.Background(), central_cognito.AppClientUpdateRequest{
+						Name:                     "fake-frontend",
+						CallbackUrls:             []string{"https://fake.vydev.io/callback"},
+						RefreshTokenValidityDays: &days,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testFakeAppClient_WithTokenSettings(`["code", "implicit"]`, "30"),
+				PlanOnly: true,
+			},
+			{
+				// A configured setting that changed outside of Terraform is planned back.
+				PreConfig: func() {
+					minutes := int64(120)
+					_, err := apis.CognitoClient.UpdateAppClient(context.Background(), central_cognito.AppClientUpdateRequest{
+						Name:                       "fake-frontend",
+						CallbackUrls:               []string{"https://fake.vydev.io/callback"},
+						AccessTokenValidityMinutes: &minutes,
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeAppClient_WithTokenSettings(`["code", "implicit"]`, "30"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testFakeAppClient_WithIdentityProviders(providers string) string {
+	return testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-internal-tool"
+	type = "frontend"
+	callback_urls = ["https://fake.vydev.io/callback"]
+	supported_identity_providers = ` + providers + `
+}
+`
+}
+
+func TestFakeAppClient_SupportedIdentityProviders(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-internal-tool"
+	type = "frontend"
+	callback_urls = ["https://fake.vydev.io/callback"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "supported_identity_providers.#", "1"),
+					resource.TestCheckTypeSetElemAttr(expected_resource_name, "supported_identity_providers.*", "COGNITO"),
+				),
+			},
+			{
+				// Restricting the client to SSO is done in place.
+				Config: testFakeAppClient_WithIdentityProviders(`["EntraID"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(expected_resource_name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "supported_identity_providers.#", "1"),
+					resource.TestCheckTypeSetElemAttr(expected_resource_name, "supported_identity_providers.*", "EntraID"),
+				),
+			},
+			{
+				Config:      testFakeAppClient_WithIdentityProviders(`["Google"]`),
+				ExpectError: regexp.MustCompile("Unable to update app client"),
+			},
+		},
+	})
+}
+
+func testFakeAppClient_WithScopes(scopes string) string {
+	return testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "fake-backend"
+	type = "backend"
+	scopes = ` + scopes + `
+}
+`
+}
+
+func TestFakeAppClient_ValidatesScopes(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["https://fake.vydev.io/demo"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/demo",
+		Name:       "demo",
+		Scopes: []central_cognito.Scope{
+			{Name: "read", Description: "Read the demo"},
+			{Name: "modify", Description: "Modify the demo"},
+		},
+	}
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				// The scope could be added in the same apply, so the plan only warns, and the remote rejects it.
+				Config:      testFakeAppClient_WithScopes(`["https://fake.vydev.io/demo/raed"]`),
+				ExpectError: regexp.MustCompile(`scope "https://fake.vydev.io/demo/raed" does not exist`),
+			},
+			{
+				Config:      testFakeAppClient_WithScopes(`["opnid"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown scopes`),
+			},
+			{
+				// The resource server of the scope could be created in the same apply, so it isn't an error.
+				Config: testFakeAppClient_WithScopes(`["openid", "https://fake.vydev.io/demo/read", "https://fake.vydev.io/other/read"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "3"),
+				),
+			},
+			{
+				Config:      testFakeAppClient_WithScopes(`["https://fake.vydev.io/demo/read", "https://fake.vydev.io/demo/modfy"]`),
+				ExpectError: regexp.MustCompile(`scope "https://fake.vydev.io/demo/modfy" does not exist`),
+			},
+		},
+	})
+}
+
+const testFakeAppClient_WithNewScope = testFake_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "https://fake.vydev.io/demo"
+	name = "demo"
+
+	scopes = [
+		{
+			name = "read"
+			description = "Allows for reading of stuff"
+		},
+		{
+			name = "modify"
+			description = "Modify stuff"
+		}
+	]
+}
+
+resource "vy_resource_server_scope" "delete" {
+	resource_server = vy_resource_server.test.identifier
+	name = "delete"
+	description = "Delete the demo"
+}
+
+resource "vy_app_client" "test" {
+	name = "fake-backend"
+	type = "backend"
+	scopes = ["https://fake.vydev.io/demo/read", "https://fake.vydev.io/demo/modify", "https://fake.vydev.io/demo/delete"]
+
+	depends_on = [vy_resource_server.test, vy_resource_server_scope.delete]
+}
+`
+
+func TestFakeAppClient_ScopesAddedInTheSameApply(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeResourceServer_Create,
+			},
+			{
+				// Both new scopes are missing in the remote while planning, but exist when the app client is created.
+				Config: testFakeAppClient_WithNewScope,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAppClientResource_ModifyPlanValidatesScopes(t *testing.T) {
+	ctx := context.Background()
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["https://fake.vydev.io/demo"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/demo",
+		Name:       "demo",
+		Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read the demo"}},
+	}
+
+	planned := &plannedScopes{}
+	planned.add("https://fake.vydev.io/demo/write")
+
+	r := AppClientResource{
+		client:        apis.CognitoClient,
+		environment:   "test",
+		userPools:     apis.local.UserPools,
+		plannedScopes: planned,
+	}
+
+	var current fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	tests := []struct {
+		name        string
+		scopes      []string
+		wantError   string
+		wantWarning string
+	}{
+		{name: "existing scope", scopes: []string{"openid", "https://fake.vydev.io/demo/read"}},
+		{name: "supported standard scopes", scopes: []string{"email", "profile", cognitoAdminScope}},
+		{name: "missing scope", scopes: []string{"https://fake.vydev.io/demo/raed"}, wantError: "did you mean https://fake.vydev.io/demo/read"},
+		{name: "planned scope", scopes: []string{"https://fake.vydev.io/demo/write"}},
+		{name: "missing resource server", scopes: []string{"https://fake.vydev.io/other/read"}, wantWarning: "resource servers that don't exist yet"},
+		{name: "unknown standard scope", scopes: []string{"opnid"}, wantError: "did you mean openid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planOf(t, current, &AppClientResourceModel{
+				Name:                       types.StringValue("my-app"),
+				Type:                       types.StringValue("backend"),
+				Scopes:                     tt.scopes,
+				AllowedOAuthFlows:          types.SetNull(types.StringType),
+				SupportedIdentityProviders: types.SetNull(types.StringType),
+				Timeouts:                   nullTimeouts(updatableResourceTimeouts),
+			})
+
+			request := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil)},
+			}
+			response := fwresource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(ctx, request, &response)
+
+			errors := response.Diagnostics.Errors()
+			if tt.wantError == "" && len(errors) > 0 {
+				t.Fatalf("expected no errors, got: %v", errors)
+			}
+			if tt.wantError != "" && (len(errors) != 1 || !strings.Contains(errors[0].Detail(), tt.wantError)) {
+				t.Fatalf("expected an error containing %q, got: %v", tt.wantError, errors)
+			}
+
+			warnings := response.Diagnostics.Warnings()
+			if tt.wantWarning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("expected no warnings, got: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), tt.wantWarning) {
+				t.Errorf("expected a warning containing %q, got: %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func testFakeAppClient_WithName(name string) string {
+	return testFake_ProviderConfig + `
+resource "vy_app_client" "test" {
+	name = "` + name + `"
+	type = "backend"
+}
+`
+}
+
+func TestFakeAppClient_Rename(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_app_client.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAppClient_WithName("fake-old-name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", "generated-client-id-fake-old-name"),
+				),
+			},
+			{
+				Config: testFakeAppClient_WithName("fake-new-name"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(expected_resource_name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "name", "fake-new-name"),
+					resource.TestCheckResourceAttr(expected_resource_name, "id", "generated-client-id-fake-old-name"),
+					resource.TestCheckResourceAttr(expected_resource_name, "client_id", "generated-client-id-fake-old-name"),
+					resource.TestCheckResourceAttr(expected_resource_name, "client_secret", "generated-secret-for-fake-old-name"),
+					func(*terraform.State) error {
+						var old central_cognito.AppClient
+						err := apis.CognitoClient.ReadAppClient(context.Background(), "fake-old-name", &old)
+						if !api_errors.IsNotFound(err) {
+							return fmt.Errorf("expected the old name to be gone, got: %v", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAppClientResource_UpgradeStateFromNameToClientId(t *testing.T) {
+	ctx := context.Background()
+	r := AppClientResource{}
+
+	var current fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	upgrader := r.UpgradeState(ctx)[0]
+
+	// The states are written by the first version of the resource, which had no other attributes.
+	tests := []struct {
+		name      string
+		rawState  string
+		wantId    string
+		wantError bool
+	}{
+		{
+			name:     "with client_id",
+			rawState: `{"id":"my-app","name":"my-app","scopes":["openid"],"type":"backend","callback_urls":null,"logout_urls":null,"generate_secret":true,"client_id":"my-client-id","client_secret":"my-secret"}`,
+			wantId:   "my-client-id",
+		},
+		{
+			name:      "without client_id",
+			rawState:  `{"id":"my-app","name":"my-app","scopes":null,"type":"backend","callback_urls":null,"logout_urls":null,"generate_secret":true,"client_id":null,"client_secret":null}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawState := tfprotov6.RawState{JSON: []byte(tt.rawState)}
+			raw, err := rawState.Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+			if err != nil {
+				t.Fatalf("could not read the prior state: %v", err)
+			}
+			prior := tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}
+
+			response := fwresource.UpgradeStateResponse{
+				State: tfsdk.State{
+					Schema: current.Schema,
+					Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			upgrader.StateUpgrader(ctx, fwresource.UpgradeStateRequest{State: &prior}, &response)
+
+			if response.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("diagnostics = %v, want error: %v", response.Diagnostics, tt.wantError)
+			}
+			if tt.wantError {
+				return
+			}
+
+			var upgraded AppClientResourceModel
+			response.Diagnostics.Append(response.State.Get(ctx, &upgraded)...)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("could not get upgraded state: %v", response.Diagnostics)
+			}
+			if upgraded.Id.ValueString() != tt.wantId || upgraded.Name.ValueString() != "my-app" {
+				t.Errorf("upgraded id = %s, name = %s, want id %s and the name kept", upgraded.Id, upgraded.Name, tt.wantId)
+			}
+			if upgraded.ClientSecret.ValueString() != "my-secret" || len(upgraded.Scopes) != 1 {
+				t.Errorf("upgraded client_secret = %s, scopes = %v, want them kept", upgraded.ClientSecret, upgraded.Scopes)
+			}
+			if upgraded.SecretRotationOverlap.ValueString() != defaultSecretRotationOverlap {
+				t.Errorf("upgraded secret_rotation_overlap = %s, want %s", upgraded.SecretRotationOverlap, defaultSecretRotationOverlap)
+			}
+		})
+	}
+}
+
+func TestAppClientResource_ConfigValidators(t *testing.T) {
+	ctx := context.Background()
+
+	var current fwresource.SchemaResponse
+	(&AppClientResource{}).Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	frontend := func(callbackUrls []string, defaultRedirectUri types.String) AppClientResourceModel {
+		return AppClientResourceModel{
+			Name:                       types.StringValue("my-app"),
+			Type:                       types.StringValue("frontend"),
+			CallbackUrls:               callbackUrls,
+			DefaultRedirectUri:         defaultRedirectUri,
+			AllowedOAuthFlows:          types.SetNull(types.StringType),
+			SupportedIdentityProviders: types.SetNull(types.StringType),
+			Timeouts:                   nullTimeouts(updatableResourceTimeouts),
+		}
+	}
+	backend := frontend([]string{"https://example.com/callback"}, types.StringNull())
+	backend.Type = types.StringValue("backend")
+
+	tests := []struct {
+		name        string
+		environment string
+		model       AppClientResourceModel
+		// unknown is a list of URLs that is unknown until the apply.
+		unknown  string
+		wantPath string
+	}{
+		{name: "frontend with urls", environment: "prod", model: frontend([]string{"https://example.com/callback"}, types.StringValue("https://example.com/callback"))},
+		{name: "backend with urls", environment: "test", model: backend, wantPath: "callback_urls"},
+		{name: "redirect uri not a callback url", environment: "test", model: frontend([]string{"https://example.com/callback"}, types.StringValue("https://example.com/other")), wantPath: "default_redirect_uri"},
+		{name: "localhost outside prod", environment: "test", model: frontend([]string{"http://localhost:3000/callback"}, types.StringNull())},
+		{name: "localhost in prod", environment: "prod", model: frontend([]string{"https://example.com/callback", "http://localhost:3000/callback"}, types.StringNull()), wantPath: "callback_urls[1]"},
+		{name: "localhost while the environment is unknown", environment: "", model: frontend([]string{"http://localhost:3000/callback"}, types.StringNull())},
+		{name: "unknown urls in prod", environment: "prod", model: frontend([]string{"http://localhost:3000/callback"}, types.StringNull()), unknown: "callback_urls"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: current.Schema,
+				Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+			}
+			if diags := plan.Set(ctx, &tt.model); diags.HasError() {
+				t.Fatalf("could not build config: %v", diags)
+			}
+			if tt.unknown != "" {
+				if diags := plan.SetAttribute(ctx, path.Root(tt.unknown), types.ListUnknown(types.StringType)); diags.HasError() {
+					t.Fatalf("could not build config: %v", diags)
+				}
+			}
+
+			request := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}
+			var response fwresource.ValidateConfigResponse
+
+			r := AppClientResource{environment: tt.environment}
+			for _, configValidator := range r.ConfigValidators(ctx) {
+				configValidator.ValidateResource(ctx, request, &response)
+			}
+
+			if tt.wantPath == "" {
+				if response.Diagnostics.HasError() {
+					t.Errorf("expected the config to be valid, got: %v", response.Diagnostics)
+				}
+				return
+			}
+
+			errors := response.Diagnostics.Errors()
+			if len(errors) != 1 {
+				t.Fatalf("expected one error, got: %v", response.Diagnostics)
+			}
+			withPath, ok := errors[0].(diag.DiagnosticWithPath)
+			if !ok || withPath.Path().String() != tt.wantPath {
+				t.Errorf("expected an error at %s, got: %v", tt.wantPath, errors[0])
+			}
+		})
+	}
+}
+
+func TestAppClientResource_UpdateSetsStateFromResponse(t *testing.T) {
+	ctx := context.Background()
+	apis := startFakeAPIs(t)
+
+	r := AppClientResource{client: apis.CognitoClient}
+
+	var current fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	created, err := apis.CognitoClient.CreateAppClient(ctx, central_cognito.AppClient{
+		Name:         "my-app",
+		Type:         "frontend",
+		CallbackUrls: []string{"https://fake.vydev.io/callback"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prior AppClientResourceModel
+	appClientResourceDataFromDomain(*created, &prior)
+	prior.SecretRotationOverlap = types.StringValue(defaultSecretRotationOverlap)
+	prior.Timeouts = nullTimeouts(updatableResourceTimeouts)
+
+	planned := prior
+	planned.CallbackUrls = []string{"https://fake.vydev.io/callback", " https://fake.vydev.io/other "}
+
+	priorState := planOf(t, current, &prior)
+	plan := planOf(t, current, &planned)
+
+	request := fwresource.UpdateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw},
+	}
+	response := fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw},
+	}
+
+	r.Update(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", response.Diagnostics)
+	}
+
+	var state AppClientResourceModel
+	response.State.Get(ctx, &state)
+
+	want := []string{"https://fake.vydev.io/callback", "https://fake.vydev.io/other"}
+	if !reflect.DeepEqual(state.CallbackUrls, want) {
+		t.Errorf("expected the callback URLs stored by the server, %v, got %v", want, state.CallbackUrls)
+	}
+	if state.SecretRotationOverlap.ValueString() != defaultSecretRotationOverlap {
+		t.Errorf("expected the secret rotation overlap to be kept, got %s", state.SecretRotationOverlap)
+	}
+}
+
+func TestAppClientResource_ModifyPlanRotatesSecretWhenTriggerChanges(t *testing.T) {
+	ctx := context.Background()
+
+	r := AppClientResource{}
+
+	var current fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	tests := []struct {
+		name       string
+		prior      types.String
+		planned    types.String
+		wantRotate bool
+	}{
+		{name: "unchanged", prior: types.StringValue("2026-01"), planned: types.StringValue("2026-01")},
+		{name: "changed", prior: types.StringValue("2026-01"), planned: types.StringValue("2026-02"), wantRotate: true},
+		{name: "set for the first time", prior: types.StringNull(), planned: types.StringValue("2026-01")},
+		{name: "removed", prior: types.StringValue("2026-01"), planned: types.StringNull()},
+		{name: "unknown until applied", prior: types.StringValue("2026-01"), planned: types.StringUnknown(), wantRotate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := AppClientResourceModel{
+				Id:                         types.StringValue("generated-client-id-my-app"),
+				Name:                       types.StringValue("my-app"),
+				Type:                       types.StringValue("backend"),
+				GenerateSecret:             types.BoolValue(true),
+				ClientId:                   types.StringValue("generated-client-id-my-app"),
+				ClientSecret:               types.StringValue("generated-secret-for-my-app"),
+				ClientSecretCreatedAt:      types.StringValue("2026-01-01T00:00:00Z"),
+				SecretRotationTrigger:      tt.prior,
+				SecretRotationOverlap:      types.StringValue(defaultSecretRotationOverlap),
+				AllowedOAuthFlows:          types.SetNull(types.StringType),
+				SupportedIdentityProviders: types.SetNull(types.StringType),
+				Timeouts:                   nullTimeouts(updatableResourceTimeouts),
+			}
+			planned := prior
+			planned.SecretRotationTrigger = tt.planned
+
+			state := planOf(t, current, &prior)
+			plan := planOf(t, current, &planned)
+
+			request := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+			}
+			response := fwresource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(ctx, request, &response)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", response.Diagnostics)
+			}
+
+			var secret types.String
+			response.Plan.GetAttribute(ctx, path.Root("client_secret"), &secret)
+			if secret.IsUnknown() != tt.wantRotate {
+				t.Errorf("expected rotation to be %v, got client_secret %s in the plan", tt.wantRotate, secret)
+			}
+		})
+	}
+}
+
+func TestAppClientResource_ImportStateFindsAppClient(t *testing.T) {
+	ctx := context.Background()
+
+	clientId := "generated-client-id-my-app"
+	fake := &fakes.CentralCognitoAPI{
+		AppClients: map[string]central_cognito.AppClient{
+			"my-app": {Name: "my-app", Type: "backend", ClientId: &clientId},
+		},
+	}
+
+	var current fwresource.SchemaResponse
+	(&AppClientResource{}).Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+	tests := []struct {
+		name      string
+		id        string
+		failReads bool
+		wantError string
+	}{
+		{name: "by name", id: "my-app"},
+		{name: "by client_id", id: clientId},
+		{name: "not found", id: "other-app", wantError: "could not be found in the new or old delegated Cognito"},
+		{name: "name not found", id: "name:other-app", wantError: "could not be found in the delegated Cognito"},
+		{name: "client_id not found", id: "client_id:other-app", wantError: "could not be found in the new or old delegated Cognito"},
+		{name: "failing read", id: "my-app", failReads: true, wantError: "Can't read app client my-app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if tt.failReads && r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				fake.ServeHTTP(w, r)
+			}))
+			t.Cleanup(server.Close)
+
+			r := AppClientResource{client: &central_cognito.Client{
+				BaseUrl:    strings.TrimPrefix(server.URL, "http://"),
+				HTTPClient: server.Client(),
+			}}
+
+			response := fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: current.Schema,
+					Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+				},
+			}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: tt.id}, &response)
+
+			errors := response.Diagnostics.Errors()
+			if tt.wantError == "" {
+				if len(errors) > 0 {
+					t.Fatalf("unexpected error: %v", errors)
+				}
+
+				var imported AppClientResourceModel
+				response.Diagnostics.Append(response.State.Get(ctx, &imported)...)
+				if imported.Name.ValueString() != "my-app" || imported.ClientId.ValueString() != clientId {
+					t.Errorf("imported name = %s, client_id = %s, want my-app and %s", imported.Name, imported.ClientId, clientId)
+				}
+				return
+			}
+
+			if len(errors) != 1 || !strings.Contains(errors[0].Detail(), tt.wantError) {
+				t.Errorf("expected an error containing %q, got: %v", tt.wantError, errors)
+			}
+			if tt.failReads && requests != 1 {
+				t.Errorf("expected no fallback to the client_id when the read fails, got %d requests", requests)
+			}
+		})
+	}
+}
+
+func TestAppClientResource_UpdateRotatesSecretWhenTriggerChanges(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		prior         types.String
+		planned       types.String
+		unknownSecret bool
+		wantRotate    bool
+	}{
+		{name: "unchanged", prior: types.StringValue("2026-01"), planned: types.StringValue("2026-01")},
+		{name: "changed", prior: types.StringValue("2026-01"), planned: types.StringValue("2026-02"), unknownSecret: true, wantRotate: true},
+		{name: "set for the first time", prior: types.StringNull(), planned: types.StringValue("2026-01")},
+		{name: "removed", prior: types.StringValue("2026-01"), planned: types.StringNull()},
+		// The trigger was unknown when planning, so the secret is unknown, but its value didn't change.
+		{name: "unchanged when applied", prior: types.StringValue("2026-01"), planned: types.StringValue("2026-01"), unknownSecret: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apis := startFakeAPIs(t)
+			r := AppClientResource{client: apis.CognitoClient}
+
+			var current fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &current)
+
+			created, err := apis.CognitoClient.CreateAppClient(ctx, central_cognito.AppClient{Name: "my-app", Type: "backend"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var prior AppClientResourceModel
+			appClientResourceDataFromDomain(*created, &prior)
+			prior.SecretRotationTrigger = tt.prior
+			prior.SecretRotationOverlap = types.StringValue(defaultSecretRotationOverlap)
+			prior.Timeouts = nullTimeouts(updatableResourceTimeouts)
+
+			// client_secret_created_at is kept known, so only the trigger decides the rotation.
+			planned := prior
+			planned.SecretRotationTrigger = tt.planned
+			if tt.unknownSecret {
+				planned.ClientSecret = types.StringUnknown()
+			}
+
+			priorState := planOf(t, current, &prior)
+			plan := planOf(t, current, &planned)
+
+			request := fwresource.UpdateRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw},
+			}
+			response := fwresource.UpdateResponse{
+				State: tfsdk.State{Schema: priorState.Schema, Raw: priorState.Raw},
+			}
+
+			r.Update(ctx, request, &response)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", response.Diagnostics)
+			}
+
+			var state AppClientResourceModel
+			response.State.Get(ctx, &state)
+
+			rotated := !state.ClientSecret.Equal(prior.ClientSecret)
+			if rotated != tt.wantRotate {
+				t.Errorf("expected rotation to be %v, got client_secret %s after %s", tt.wantRotate, state.ClientSecret, prior.ClientSecret)
+			}
+			if !state.SecretRotationTrigger.Equal(tt.planned) {
+				t.Errorf("expected secret_rotation_trigger %s in the state, got %s", tt.planned, state.SecretRotationTrigger)
+			}
+		})
+	}
+}
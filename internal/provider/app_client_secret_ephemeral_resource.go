@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &AppClientSecretEphemeralResource{}
+
+func NewAppClientSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &AppClientSecretEphemeralResource{}
+}
+
+type AppClientSecretEphemeralResource struct {
+	client *central_cognito.Client
+}
+
+type AppClientSecretEphemeralResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+func (a *AppClientSecretEphemeralResource) Metadata(ctx context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_app_client_secret"
+}
+
+func (a *AppClientSecretEphemeralResource) Schema(ctx context.Context, request ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the secret of an app client when Terraform runs, without storing it in the plan or state. " +
+			"Use it to pass the secret on to a secrets store through a write-only attribute.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the app client",
+				Required:            true,
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The ID used for your client to authenticate itself.",
+				Computed:            true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The secret used for your client to authenticate itself.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (a *AppClientSecretEphemeralResource) Configure(ctx context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = configuration.CognitoClient
+}
+
+func (a *AppClientSecretEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var data AppClientSecretEphemeralResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var appClient central_cognito.AppClient
+	err := a.client.ReadAppClient(ctx, data.Name.ValueString(), &appClient)
+	if api_errors.IsNotFound(err) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"App client not found",
+			fmt.Sprintf("There is no app client named %s.", data.Name.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read app client",
+			fmt.Sprintf("Can't read app client %s from remote: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if appClient.ClientSecret == nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"App client has no secret",
+			fmt.Sprintf("The app client %s was created without a secret. Set `generate_secret` or use the `backend` type to get one.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	data.ClientId = types.StringPointerValue(appClient.ClientId)
+	data.ClientSecret = types.StringValue(*appClient.ClientSecret)
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
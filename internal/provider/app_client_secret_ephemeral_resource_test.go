@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+// providerFactoriesWithEcho adds the echo provider, which copies an ephemeral value into state
+// so it can be checked. Ephemeral values are never stored in state otherwise.
+func (apis *fakeAPIs) providerFactoriesWithEcho() map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := apis.providerFactories()
+	factories["echo"] = echoprovider.NewProviderServer()
+
+	return factories
+}
+
+func testFakeAppClientSecretConfig(name string) string {
+	return testFake_ProviderConfig + `
+ephemeral "vy_app_client_secret" "this" {
+	name = "` + name + `"
+}
+
+provider "echo" {
+	data = ephemeral.vy_app_client_secret.this
+}
+
+resource "echo" "this" {}
+`
+}
+
+func TestFakeAppClientSecret(t *testing.T) {
+	apis := startFakeAPIs(t)
+
+	clientId := "fake-client-id"
+	clientSecret := "fake-client-secret"
+	apis.Cognito.AppClients["fake-backend"] = central_cognito.AppClient{
+		Name:         "fake-backend",
+		Type:         "backend",
+		ClientId:     &clientId,
+		ClientSecret: &clientSecret,
+	}
+	apis.Cognito.AppClients["fake-frontend"] = central_cognito.AppClient{
+		Name:     "fake-frontend",
+		Type:     "frontend",
+		ClientId: &clientId,
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactoriesWithEcho(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeAppClientSecretConfig("fake-backend"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.this", "data.client_id", "fake-client-id"),
+					resource.TestCheckResourceAttr("echo.this", "data.client_secret", "fake-client-secret"),
+				),
+			},
+			{
+				Config:      testFakeAppClientSecretConfig("fake-frontend"),
+				ExpectError: regexp.MustCompile("App client has no secret"),
+			},
+			{
+				Config:      testFakeAppClientSecretConfig("does-not-exist"),
+				ExpectError: regexp.MustCompile("App client not found"),
+			},
+		},
+	})
+}
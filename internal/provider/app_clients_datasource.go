@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &AppClientsDataSource{}
+
+func NewAppClientsDataSource() datasource.DataSource {
+	return &AppClientsDataSource{}
+}
+
+type AppClientsDataSource struct {
+	client *central_cognito.Client
+}
+
+type AppClientsDataSourceModel struct {
+	Id         types.String    `tfsdk:"id"`
+	NamePrefix types.String    `tfsdk:"name_prefix"`
+	Type       types.String    `tfsdk:"type"`
+	Scope      types.String    `tfsdk:"scope"`
+	AppClients []appClientItem `tfsdk:"app_clients"`
+}
+
+// appClientItem is a listed app client. Like the vy_app_client data source, it leaves out the secret.
+type appClientItem struct {
+	Name           types.String `tfsdk:"name"`
+	ClientId       types.String `tfsdk:"client_id"`
+	Type           types.String `tfsdk:"type"`
+	Scopes         []string     `tfsdk:"scopes"`
+	CallbackUrls   []string     `tfsdk:"callback_urls"`
+	LogoutUrls     []string     `tfsdk:"logout_urls"`
+	GenerateSecret types.Bool   `tfsdk:"generate_secret"`
+}
+
+func (a AppClientsDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_app_clients"
+}
+
+func (a AppClientsDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "List the app clients registered in the shared Cognito, optionally filtered. " +
+			"The secrets of the app clients are not included.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list app clients with a name that starts with this prefix",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list app clients of this type. Must be either `frontend` or `backend`.",
+				Optional:            true,
+				Validators: []validator.String{
+					frontendOrBackendValidator{},
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Only list app clients that have access to this scope",
+				Optional:            true,
+			},
+			"app_clients": schema.ListNestedAttribute{
+				MarkdownDescription: "The app clients, sorted by their name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the app client",
+							Computed:            true,
+						},
+						"client_id": schema.StringAttribute{
+							MarkdownDescription: "The ID the app client uses to authenticate itself",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The use-case for the app client. Either `frontend` or `backend`.",
+							Computed:            true,
+						},
+						"scopes": schema.SetAttribute{
+							MarkdownDescription: "Scopes that the app client has access to",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"callback_urls": schema.ListAttribute{
+							MarkdownDescription: "Callback URLs of the app client",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"logout_urls": schema.ListAttribute{
+							MarkdownDescription: "Logout URLs of the app client",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"generate_secret": schema.BoolAttribute{
+							MarkdownDescription: "Whether the app client has a secret",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *AppClientsDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = configuration.CognitoClient
+}
+
+func (a AppClientsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state AppClientsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	appClients, err := a.client.ListAppClients(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to list app clients",
+			fmt.Sprintf("Can't list app clients from remote: %s", err.Error()),
+		)
+		return
+	}
+
+	slices.SortFunc(appClients, func(a, b central_cognito.AppClient) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	state.Id = types.StringValue("app-clients")
+	state.AppClients = []appClientItem{}
+
+	for _, appClient := range appClients {
+		if !strings.HasPrefix(appClient.Name, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if !state.Type.IsNull() && appClient.Type != state.Type.ValueString() {
+			continue
+		}
+		if !state.Scope.IsNull() && !slices.Contains(appClient.Scopes, state.Scope.ValueString()) {
+			continue
+		}
+
+		state.AppClients = append(state.AppClients, appClientItem{
+			Name:           types.StringValue(appClient.Name),
+			ClientId:       types.StringPointerValue(appClient.ClientId),
+			Type:           types.StringValue(appClient.Type),
+			Scopes:         appClient.Scopes,
+			CallbackUrls:   appClient.CallbackUrls,
+			LogoutUrls:     appClient.LogoutUrls,
+			GenerateSecret: types.BoolPointerValue(appClient.GenerateSecret),
+		})
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeAppClientsDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.PageSize = 2
+
+	for _, appClient := range []central_cognito.AppClient{
+		{Name: "trains-backend", Type: "backend", Scopes: []string{"trains.fake.io/read"}},
+		{Name: "trains-frontend", Type: "frontend", Scopes: []string{"email", "openid"}},
+		{Name: "trains-worker", Type: "backend", Scopes: []string{"trains.fake.io/read", "trains.fake.io/write"}},
+		{Name: "buses-backend", Type: "backend", Scopes: []string{"trains.fake.io/read"}},
+		{Name: "buses-frontend", Type: "frontend"},
+	} {
+		apis.Cognito.AppClients[appClient.Name] = appClient
+	}
+	expected_resource_name := "data.vy_app_clients.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `data "vy_app_clients" "this" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.#", "5"),
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.0.name", "buses-backend"),
+				),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_clients" "this" {
+	name_prefix = "trains-"
+	type        = "backend"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.0.name", "trains-backend"),
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.1.name", "trains-worker"),
+				),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_app_clients" "this" {
+	scope = "trains.fake.io/write"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.#", "1"),
+					resource.TestCheckResourceAttr(expected_resource_name, "app_clients.0.name", "trains-worker"),
+				),
+			},
+		},
+	})
+}
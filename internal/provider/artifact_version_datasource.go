@@ -96,7 +96,7 @@ func (a ArtifactVersionDataSource) Read(ctx context.Context, request datasource.
 	}
 
 	var version version_handler.Version
-	err := a.client.ReadVersion(state.Application.ValueString(), &version)
+	err := a.client.ReadVersion(ctx, state.Application.ValueString(), &version)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Unable to read artifact version",
@@ -1,5 +1,12 @@
 package provider
 
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
+)
+
 const testAccArtifactVersion = testAcc_ProviderConfig + `
 data "vy_artifact_version" "this" {
 	application = "petstore-webapp"
@@ -32,3 +39,39 @@ data "vy_artifact_version" "this" {
 //		},
 //	})
 //}
+
+const testFakeArtifactVersion = testFake_ProviderConfig + `
+data "vy_artifact_version" "this" {
+	application = "petstore-webapp"
+}
+`
+
+func TestFakeArtifactVersion_Basic(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.VersionHandler.Versions["petstore-webapp"] = version_handler.Version{
+		ApplicationName: "petstore-webapp",
+		URI:             "s3://artifacts/petstore-webapp/abc123.zip",
+		Store:           "artifacts",
+		Path:            "petstore-webapp/abc123.zip",
+		Version:         "abc123",
+	}
+
+	expected_resource_name := "data.vy_artifact_version.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeArtifactVersion,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "application", "petstore-webapp"),
+					resource.TestCheckResourceAttr(expected_resource_name, "uri", "s3://artifacts/petstore-webapp/abc123.zip"),
+					resource.TestCheckResourceAttr(expected_resource_name, "store", "artifacts"),
+					resource.TestCheckResourceAttr(expected_resource_name, "path", "petstore-webapp/abc123.zip"),
+					resource.TestCheckResourceAttr(expected_resource_name, "version", "abc123"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &CognitoAccessTokenEphemeralResource{}
+
+func NewCognitoAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &CognitoAccessTokenEphemeralResource{}
+}
+
+type CognitoAccessTokenEphemeralResource struct {
+	environment string
+	userPools   map[string]userPool
+	client      *central_cognito.Client
+}
+
+type CognitoAccessTokenEphemeralResourceModel struct {
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       []string     `tfsdk:"scopes"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	TokenType    types.String `tfsdk:"token_type"`
+	ExpiresIn    types.Int64  `tfsdk:"expires_in"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+}
+
+func (c *CognitoAccessTokenEphemeralResource) Metadata(ctx context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_cognito_access_token"
+}
+
+func (c *CognitoAccessTokenEphemeralResource) Schema(ctx context.Context, request ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Gets an access token for an app client from the shared Cognito user pool, using the `client_credentials` grant. " +
+			"The token is not stored in the plan or state. Use it for smoke tests, or to configure services that call APIs protected by Cognito.",
+
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the app client",
+				Required:            true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The secret of the app client. Only `backend` app clients have one.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "The scopes to request. Defaults to all the scopes of the app client.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "The access token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the token, usually `Bearer`",
+				Computed:            true,
+			},
+			"expires_in": schema.Int64Attribute{
+				MarkdownDescription: "How many seconds the token is valid for",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the token expires, in RFC 3339 format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (c *CognitoAccessTokenEphemeralResource) Configure(ctx context.Context, request ephemeral.ConfigureRequest, response *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	c.environment = configuration.Environment
+	c.userPools = configuration.userPools
+	c.client = configuration.CognitoClient
+}
+
+func (c *CognitoAccessTokenEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var data CognitoAccessTokenEphemeralResourceModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := readCognitoInfo(ctx, c.client, c.environment, c.userPools)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read Cognito info",
+			fmt.Sprintf("Can't find the token endpoint of the environment %s: %s", c.environment, err.Error()),
+		)
+		return
+	}
+
+	requestedAt := time.Now()
+	token, err := c.client.RequestClientCredentialsToken(ctx, info.TokenEndpoint, data.ClientId.ValueString(), data.ClientSecret.ValueString(), data.Scopes)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to get access token",
+			fmt.Sprintf("Can't get an access token for app client %s: %s", data.ClientId.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.TokenType = types.StringValue(token.TokenType)
+	data.ExpiresIn = types.Int64Value(token.ExpiresIn)
+	data.ExpiresAt = types.StringValue(requestedAt.Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339))
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
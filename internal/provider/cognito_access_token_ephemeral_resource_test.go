@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func testFakeCognitoAccessTokenConfig(clientSecret string, scopes string) string {
+	return testFake_ProviderConfig + `
+ephemeral "vy_cognito_access_token" "this" {
+	client_id     = "fake-client-id"
+	client_secret = "` + clientSecret + `"
+	scopes        = ` + scopes + `
+}
+
+provider "echo" {
+	data = ephemeral.vy_cognito_access_token.this
+}
+
+resource "echo" "this" {}
+`
+}
+
+func TestFakeCognitoAccessToken(t *testing.T) {
+	apis := startFakeAPIs(t)
+
+	clientId := "fake-client-id"
+	clientSecret := "fake-client-secret"
+	apis.Cognito.AppClients["fake-backend"] = central_cognito.AppClient{
+		Name:         "fake-backend",
+		Type:         "backend",
+		Scopes:       []string{"fake.vydev.io/read"},
+		ClientId:     &clientId,
+		ClientSecret: &clientSecret,
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactoriesWithEcho(),
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeCognitoAccessTokenConfig("fake-client-secret", `["fake.vydev.io/read"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("echo.this", "data.access_token", regexp.MustCompile("^fake-access-token-\\d+-for-fake-backend$")),
+					resource.TestCheckResourceAttr("echo.this", "data.token_type", "Bearer"),
+					resource.TestCheckResourceAttr("echo.this", "data.expires_in", "3600"),
+					resource.TestCheckResourceAttrSet("echo.this", "data.expires_at"),
+				),
+			},
+			{
+				Config:      testFakeCognitoAccessTokenConfig("wrong-secret", "null"),
+				ExpectError: regexp.MustCompile("invalid_client"),
+			},
+			{
+				Config:      testFakeCognitoAccessTokenConfig("fake-client-secret", `["fake.vydev.io/write"]`),
+				ExpectError: regexp.MustCompile("invalid_scope"),
+			},
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
 )
 
@@ -18,15 +19,22 @@ func NewCognitoInfoDataSource() datasource.DataSource {
 
 type CognitoInfoDataSource struct {
 	environment string
+	userPools   map[string]userPool
 	client      *central_cognito.Client
 }
 
 type CognitoInfoDataSourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	AuthUrl   types.String `tfsdk:"auth_url"`
-	JwksUrl   types.String `tfsdk:"jwks_url"`
-	OpenIdUrl types.String `tfsdk:"open_id_url"`
-	Issuer    types.String `tfsdk:"issuer"`
+	Id               types.String `tfsdk:"id"`
+	AuthUrl          types.String `tfsdk:"auth_url"`
+	JwksUrl          types.String `tfsdk:"jwks_url"`
+	OpenIdUrl        types.String `tfsdk:"open_id_url"`
+	Issuer           types.String `tfsdk:"issuer"`
+	TokenEndpoint    types.String `tfsdk:"token_endpoint"`
+	UserinfoEndpoint types.String `tfsdk:"userinfo_endpoint"`
+	LogoutEndpoint   types.String `tfsdk:"logout_endpoint"`
+	UserPoolId       types.String `tfsdk:"user_pool_id"`
+	Region           types.String `tfsdk:"region"`
+	SupportedScopes  []string     `tfsdk:"supported_scopes"`
 }
 
 func (c *CognitoInfoDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
@@ -35,6 +43,11 @@ func (c *CognitoInfoDataSource) Metadata(ctx context.Context, request datasource
 
 func (c *CognitoInfoDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
 	response.Schema = schema.Schema{
+		MarkdownDescription: "Get information about the shared Cognito user pool of the provider's environment. " +
+			"The user pools of `prod`, `stage`, `test` and `dev` are read from their public OpenID configuration, " +
+			"falling back to the default URLs of Cognito when it can't be read. " +
+			"Other environments are read from the delegated Cognito, which needs AWS credentials.",
+
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -55,6 +68,31 @@ func (c *CognitoInfoDataSource) Schema(ctx context.Context, request datasource.S
 				Computed:            true,
 				MarkdownDescription: "The URI for the issuer",
 			},
+			"token_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL where clients get their tokens",
+			},
+			"userinfo_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL where clients get information about the authenticated user",
+			},
+			"logout_endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL where users are signed out",
+			},
+			"user_pool_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the user pool",
+			},
+			"region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The AWS region of the user pool",
+			},
+			"supported_scopes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The standard OpenID Connect scopes that the user pool supports",
+			},
 		},
 	}
 }
@@ -77,77 +115,40 @@ func (c *CognitoInfoDataSource) Configure(ctx context.Context, request datasourc
 	}
 
 	c.environment = configuration.Environment
+	c.userPools = configuration.userPools
 	c.client = configuration.CognitoClient
 }
 
 func (c *CognitoInfoDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
-	// TODO: This should be fetched from the service.
-	//	     Doing it quickly now to get the feature shipped.
-	var state CognitoInfoDataSourceModel
-
-	if c.environment == "prod" {
-		state = CognitoInfoDataSourceModel{
-			AuthUrl: types.StringValue(
-				"https://auth.cognito.vydev.io",
-			),
-			JwksUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_e6o46c1oE/.well-known/jwks.json",
-			),
-			OpenIdUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_e6o46c1oE/.well-known/openid-configuration",
-			),
-			Issuer: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_e6o46c1oE",
-			),
-		}
-	} else if c.environment == "stage" {
-		state = CognitoInfoDataSourceModel{
-			AuthUrl: types.StringValue(
-				"https://auth.stage.cognito.vydev.io",
-			),
-			JwksUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_AUYQ679zW/.well-known/jwks.json",
-			),
-			OpenIdUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_AUYQ679zW/.well-known/openid-configuration",
-			),
-			Issuer: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_AUYQ679zW",
-			),
-		}
-	} else if c.environment == "test" {
-		state = CognitoInfoDataSourceModel{
-			AuthUrl: types.StringValue(
-				"https://auth.test.cognito.vydev.io",
-			),
-			JwksUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT/.well-known/jwks.json",
-			),
-			OpenIdUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT/.well-known/openid-configuration",
-			),
-			Issuer: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT",
-			),
-		}
-	} else if c.environment == "dev" {
-		state = CognitoInfoDataSourceModel{
-			AuthUrl: types.StringValue(
-				"https://auth.dev.cognito.vydev.io",
-			),
-			JwksUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_0AvVv5Wyk/.well-known/jwks.json",
-			),
-			OpenIdUrl: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_0AvVv5Wyk/.well-known/openid-configuration",
-			),
-			Issuer: types.StringValue(
-				"https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_0AvVv5Wyk",
-			),
-		}
+	info, err := readCognitoInfo(ctx, c.client, c.environment, c.userPools)
+	if api_errors.IsNotFound(err) {
+		response.Diagnostics.AddError(
+			"Unknown environment",
+			fmt.Sprintf("There is no shared Cognito user pool in the environment %s.", c.environment),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read Cognito info",
+			fmt.Sprintf("Can't read the user pool of the environment %s from remote. Is it a known environment? %s", c.environment, err.Error()),
+		)
+		return
 	}
 
-	state.Id = types.StringValue(c.environment)
+	state := CognitoInfoDataSourceModel{
+		Id:               types.StringValue(c.environment),
+		AuthUrl:          types.StringValue(info.AuthUrl),
+		JwksUrl:          types.StringValue(info.JwksUrl),
+		OpenIdUrl:        types.StringValue(info.OpenIdUrl),
+		Issuer:           types.StringValue(info.Issuer),
+		TokenEndpoint:    types.StringValue(info.TokenEndpoint),
+		UserinfoEndpoint: types.StringValue(info.UserinfoEndpoint),
+		LogoutEndpoint:   types.StringValue(info.LogoutEndpoint),
+		UserPoolId:       types.StringValue(info.UserPoolId),
+		Region:           types.StringValue(info.Region),
+		SupportedScopes:  info.ScopesSupported,
+	}
 
-	response.State.Set(ctx, &state)
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -26,8 +27,61 @@ func TestAccCognitoInfo(t *testing.T) {
 					resource.TestCheckResourceAttrSet(expected_resource_name, "jwks_url"),
 					resource.TestCheckResourceAttrSet(expected_resource_name, "open_id_url"),
 					resource.TestCheckResourceAttrSet(expected_resource_name, "issuer"),
+					resource.TestCheckResourceAttrSet(expected_resource_name, "token_endpoint"),
+					resource.TestCheckResourceAttrSet(expected_resource_name, "user_pool_id"),
+					resource.TestCheckResourceAttrSet(expected_resource_name, "region"),
 				),
 			},
 		},
 	})
 }
+
+func TestFakeCognitoInfo(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "data.vy_cognito_info.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `data "vy_cognito_info" "this" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", "test"),
+					resource.TestCheckResourceAttr(expected_resource_name, "auth_url", fakeCognitoInfo.AuthUrl),
+					resource.TestCheckResourceAttr(expected_resource_name, "jwks_url", apis.Cognito.Info.JwksUrl),
+					resource.TestCheckResourceAttr(expected_resource_name, "open_id_url", apis.Cognito.Info.OpenIdUrl),
+					resource.TestCheckResourceAttr(expected_resource_name, "issuer", apis.Cognito.Info.Issuer),
+					resource.TestCheckResourceAttr(expected_resource_name, "token_endpoint", apis.Cognito.Info.TokenEndpoint),
+					resource.TestCheckResourceAttr(expected_resource_name, "userinfo_endpoint", fakeCognitoInfo.UserinfoEndpoint),
+					resource.TestCheckResourceAttr(expected_resource_name, "logout_endpoint", fakeCognitoInfo.LogoutEndpoint),
+					resource.TestCheckResourceAttr(expected_resource_name, "user_pool_id", fakeCognitoInfo.UserPoolId),
+					resource.TestCheckResourceAttr(expected_resource_name, "region", fakeCognitoInfo.Region),
+					resource.TestCheckResourceAttr(expected_resource_name, "supported_scopes.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestFakeCognitoInfo_UnknownEnvironment(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.Info = nil
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "vy" {
+	environment = "unknown"
+}
+
+data "vy_cognito_info" "this" {}
+`,
+				ExpectError: regexp.MustCompile("Unknown environment"),
+			},
+		},
+	})
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &CognitoJwksDataSource{}
+
+func NewCognitoJwksDataSource() datasource.DataSource {
+	return &CognitoJwksDataSource{}
+}
+
+type CognitoJwksDataSource struct {
+	environment string
+	userPools   map[string]userPool
+	client      *central_cognito.Client
+}
+
+type CognitoJwksDataSourceModel struct {
+	Id      types.String     `tfsdk:"id"`
+	JwksUrl types.String     `tfsdk:"jwks_url"`
+	Keys    []jsonWebKeyItem `tfsdk:"keys"`
+}
+
+type jsonWebKeyItem struct {
+	Kid types.String `tfsdk:"kid"`
+	Alg types.String `tfsdk:"alg"`
+	Kty types.String `tfsdk:"kty"`
+	Use types.String `tfsdk:"use"`
+	N   types.String `tfsdk:"n"`
+	E   types.String `tfsdk:"e"`
+	Pem types.String `tfsdk:"pem"`
+}
+
+func (c *CognitoJwksDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_cognito_jwks"
+}
+
+func (c *CognitoJwksDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Get the keys that the shared Cognito user pool signs tokens with. " +
+			"Use it to configure authorizers that can't fetch the JWKS document themselves.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"jwks_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL the keys were read from. The same as `jwks_url` of `vy_cognito_info`.",
+			},
+			"keys": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The signing keys of the user pool",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the key, matching the `kid` header of the tokens it signed",
+						},
+						"alg": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The algorithm the key signs with, e.g. `RS256`",
+						},
+						"kty": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the key, e.g. `RSA`",
+						},
+						"use": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "What the key is used for. `sig` for signing keys.",
+						},
+						"n": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The modulus of the RSA key, base64url encoded",
+						},
+						"e": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The exponent of the RSA key, base64url encoded",
+						},
+						"pem": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The public key, PEM encoded",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *CognitoJwksDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	c.environment = configuration.Environment
+	c.userPools = configuration.userPools
+	c.client = configuration.CognitoClient
+}
+
+func (c *CognitoJwksDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	info, err := readCognitoInfo(ctx, c.client, c.environment, c.userPools)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read Cognito info",
+			fmt.Sprintf("Can't find the user pool of the environment %s: %s", c.environment, err.Error()),
+		)
+		return
+	}
+
+	var jwks central_cognito.JsonWebKeySet
+	err = c.client.ReadJwks(ctx, info.JwksUrl, &jwks)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read signing keys",
+			fmt.Sprintf("Can't read the JWKS document at %s: %s", info.JwksUrl, err.Error()),
+		)
+		return
+	}
+
+	state := CognitoJwksDataSourceModel{
+		Id:      types.StringValue(c.environment),
+		JwksUrl: types.StringValue(info.JwksUrl),
+		Keys:    []jsonWebKeyItem{},
+	}
+
+	for _, key := range jwks.Keys {
+		encoded, err := key.PublicKeyPEM()
+		if err != nil {
+			response.Diagnostics.AddError(
+				"Unable to encode signing key",
+				fmt.Sprintf("Can't encode the key %s as PEM: %s", key.Kid, err.Error()),
+			)
+			return
+		}
+
+		state.Keys = append(state.Keys, jsonWebKeyItem{
+			Kid: types.StringValue(key.Kid),
+			Alg: types.StringValue(key.Alg),
+			Kty: types.StringValue(key.Kty),
+			Use: types.StringValue(key.Use),
+			N:   types.StringValue(key.N),
+			E:   types.StringValue(key.E),
+			Pem: types.StringValue(encoded),
+		})
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
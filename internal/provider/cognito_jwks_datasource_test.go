@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeCognitoJwks(t *testing.T) {
+	apis := startFakeAPIs(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apis.Cognito.Jwks = &central_cognito.JsonWebKeySet{
+		Keys: []central_cognito.JsonWebKey{
+			{
+				Kid: "fake-key",
+				Alg: "RS256",
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	}
+	expected_resource_name := "data.vy_cognito_jwks.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `data "vy_cognito_jwks" "this" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "jwks_url", apis.Cognito.Info.JwksUrl),
+					resource.TestCheckResourceAttr(expected_resource_name, "keys.#", "1"),
+					resource.TestCheckResourceAttr(expected_resource_name, "keys.0.kid", "fake-key"),
+					resource.TestCheckResourceAttr(expected_resource_name, "keys.0.alg", "RS256"),
+					resource.TestCheckResourceAttr(expected_resource_name, "keys.0.use", "sig"),
+					resource.TestCheckResourceAttr(expected_resource_name, "keys.0.e", "AQAB"),
+					resource.TestMatchResourceAttr(expected_resource_name, "keys.0.pem", regexp.MustCompile("^-----BEGIN PUBLIC KEY-----\n")),
+				),
+			},
+		},
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
 )
 
@@ -23,6 +26,8 @@ type DeploymentAccountResource struct {
 type DeploymentAccountResourceModel struct {
 	Id           types.String `tfsdk:"id"`
 	SlackChannel types.String `tfsdk:"slack_channel"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d DeploymentAccountResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -47,6 +52,9 @@ func (d DeploymentAccountResource) Schema(ctx context.Context, request resource.
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, replacedResourceTimeouts),
+		},
 	}
 }
 
@@ -85,7 +93,16 @@ func (d DeploymentAccountResource) Create(ctx context.Context, request resource.
 		return
 	}
 
-	created, err := d.client.CreateDeploymentAccount(
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := d.client.CreateDeploymentAccount(ctx,
 		data.SlackChannel.ValueString(),
 	)
 	if err != nil {
@@ -97,7 +114,7 @@ func (d DeploymentAccountResource) Create(ctx context.Context, request resource.
 		return
 	}
 
-	var createdData DeploymentAccountResourceModel
+	createdData := DeploymentAccountResourceModel{Timeouts: data.Timeouts}
 	deployAccountDomainToState(created, &createdData)
 
 	diags = response.State.Set(ctx, &createdData)
@@ -114,9 +131,25 @@ func (d DeploymentAccountResource) Read(ctx context.Context, request resource.Re
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var readData enroll_account.DeploymentAccount
-	err := d.client.ReadDeploymentAccount(&readData)
+	err := d.client.ReadDeploymentAccount(ctx, &readData)
 
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "Deployment account is no longer enrolled, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Unable to read deployment account information",
@@ -158,7 +191,16 @@ func (d DeploymentAccountResource) Delete(ctx context.Context, request resource.
 		return
 	}
 
-	err := d.client.DeleteDeploymentAccount()
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := d.client.DeleteDeploymentAccount(ctx)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Could not delete account",
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -32,3 +34,49 @@ func TestAccDeploymentAccount(t *testing.T) {
 		},
 	})
 }
+
+func testFakeDeploymentAccountConfig(slack_channel string) string {
+	return testFake_ProviderConfig + fmt.Sprintf(`
+resource "vy_deployment_account" "test" {
+	slack_channel = "%s"
+}
+`, slack_channel)
+}
+
+func TestFakeDeploymentAccount_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_deployment_account.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeDeploymentAccountConfig("CMN2KHQL8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", fakeCallerAccountId),
+					resource.TestCheckResourceAttr(expected_resource_name, "slack_channel", "CMN2KHQL8"),
+				),
+			},
+			{
+				// Changing slack_channel replaces the enrollment.
+				Config: testFakeDeploymentAccountConfig("C0123456789"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", fakeCallerAccountId),
+					resource.TestCheckResourceAttr(expected_resource_name, "slack_channel", "C0123456789"),
+				),
+			},
+			{
+				// The account is unenrolled outside of Terraform, so it should be planned to be enrolled again.
+				PreConfig: func() {
+					if err := apis.EnrollAccountClient.DeleteDeploymentAccount(context.Background()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeDeploymentAccountConfig("C0123456789"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -111,7 +111,7 @@ func (e ECSImageDataSource) Read(ctx context.Context, request datasource.ReadReq
 	}
 
 	var version version_handler_v2.ECSVersion
-	err := e.client.ReadECSImage(
+	err := e.client.ReadECSImage(ctx,
 		state.GitHubRepositoryName.ValueString(),
 		state.ECRRepositoryName.ValueString(),
 		state.WorkingDirectory.ValueString(),
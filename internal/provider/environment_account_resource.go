@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
 )
 
@@ -23,6 +26,8 @@ type EnvironmentAccountResource struct {
 type EnvironmentAccountResourceModel struct {
 	Id             types.String `tfsdk:"id"`
 	OwnerAccountId types.String `tfsdk:"owner_account_id"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (e EnvironmentAccountResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -47,6 +52,9 @@ func (e EnvironmentAccountResource) Schema(ctx context.Context, request resource
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, replacedResourceTimeouts),
+		},
 	}
 }
 
@@ -84,7 +92,16 @@ func (e EnvironmentAccountResource) Create(ctx context.Context, request resource
 		return
 	}
 
-	registered, err := e.client.RegisterEnvironmentAccount(data.OwnerAccountId.ValueString())
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	registered, err := e.client.RegisterEnvironmentAccount(ctx, data.OwnerAccountId.ValueString())
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Could not enroll environment account",
@@ -93,7 +110,7 @@ func (e EnvironmentAccountResource) Create(ctx context.Context, request resource
 		return
 	}
 
-	var registeredData EnvironmentAccountResourceModel
+	registeredData := EnvironmentAccountResourceModel{Timeouts: data.Timeouts}
 	environmentAccountDomainToState(registered, &registeredData)
 
 	diags = response.State.Set(ctx, &registeredData)
@@ -109,9 +126,25 @@ func (e EnvironmentAccountResource) Read(ctx context.Context, request resource.R
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var readData enroll_account.EnvironmentAccount
-	err := e.client.ReadEnvironmentAccount(&readData)
+	err := e.client.ReadEnvironmentAccount(ctx, &readData)
 
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "Environment account is no longer enrolled, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Unable to read environment account information",
@@ -153,7 +186,16 @@ func (e EnvironmentAccountResource) Delete(ctx context.Context, request resource
 		return
 	}
 
-	err := e.client.DeleteEnvironmentAccount()
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := e.client.DeleteEnvironmentAccount(ctx)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Could not delete account",
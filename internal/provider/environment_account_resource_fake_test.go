@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The tests against the real service in environment_account_resource_test.go are behind the extra_test build tag.
+// These run against the fake APIs, so they are always included.
+
+func testFakeEnvironmentAccountConfig(owner_account_id string) string {
+	return testFake_ProviderConfig + fmt.Sprintf(`
+resource "vy_environment_account" "test" {
+	owner_account_id = "%s"
+}
+`, owner_account_id)
+}
+
+func TestFakeEnvironmentAccount_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_environment_account.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeEnvironmentAccountConfig("210987654321"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", fakeCallerAccountId),
+					resource.TestCheckResourceAttr(expected_resource_name, "owner_account_id", "210987654321"),
+				),
+			},
+			{
+				// Changing owner_account_id replaces the enrollment.
+				Config: testFakeEnvironmentAccountConfig("111111111111"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", fakeCallerAccountId),
+					resource.TestCheckResourceAttr(expected_resource_name, "owner_account_id", "111111111111"),
+				),
+			},
+			{
+				// The account is unenrolled outside of Terraform, so it should be planned to be enrolled again.
+				PreConfig: func() {
+					if err := apis.EnrollAccountClient.DeleteEnvironmentAccount(context.Background()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeEnvironmentAccountConfig("111111111111"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
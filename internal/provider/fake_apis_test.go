@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
+)
+
+// testFake_ProviderConfig is the provider block for tests running against the fake APIs.
+// The services are selected by the provider factories of the fakes, not by the config.
+const testFake_ProviderConfig = `
+provider "vy" {
+	environment = "test"
+}
+
+`
+
+const fakeCallerAccountId = "123456789012"
+
+// fakeCognitoInfo is the discovery document of the user pool in the test environment.
+// Its issuer and the URLs below it, and the token_endpoint, are replaced by the ones of the fake when it is started,
+// so the OpenID configuration of the user pool is read from the fake.
+var fakeCognitoInfo = central_cognito.CognitoInfo{
+	UserPoolId:       "eu-west-1_Z53b9AbeT",
+	Region:           "eu-west-1",
+	AuthUrl:          "https://auth.test.cognito.vydev.io",
+	Issuer:           "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT",
+	JwksUrl:          "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT/.well-known/jwks.json",
+	OpenIdUrl:        "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT/.well-known/openid-configuration",
+	TokenEndpoint:    "https://auth.test.cognito.vydev.io/oauth2/token",
+	UserinfoEndpoint: "https://auth.test.cognito.vydev.io/oauth2/userInfo",
+	LogoutEndpoint:   "https://auth.test.cognito.vydev.io/logout",
+	ScopesSupported:  []string{"openid", "email", "phone", "profile"},
+}
+
+// fakeAPIs holds the fakes of all our services, and clients that talk to them.
+// Use the clients to change the fakes while a test runs, e.g. to delete a resource behind Terraform's back.
+type fakeAPIs struct {
+	Cognito          *fakes.CentralCognitoAPI
+	EnrollAccount    *fakes.EnrollAccountAPI
+	VersionHandler   *fakes.VersionHandlerAPI
+	VersionHandlerV2 *fakes.VersionHandlerV2API
+
+	CognitoClient       *central_cognito.Client
+	EnrollAccountClient *enroll_account.Client
+
+	local localServices
+}
+
+// startFakeAPIs starts empty fakes of all our services until the test is done.
+// Run the provider against them with providerFactories.
+func startFakeAPIs(t *testing.T) *fakeAPIs {
+	t.Helper()
+
+	info := fakeCognitoInfo
+	apis := &fakeAPIs{
+		Cognito: &fakes.CentralCognitoAPI{
+			AppClients:      map[string]central_cognito.AppClient{},
+			ResourceServers: map[string]central_cognito.ResourceServer{},
+			IdentityProviders: map[string]central_cognito.IdentityProvider{
+				"COGNITO": {Name: "COGNITO", Type: "COGNITO"},
+				"EntraID": {Name: "EntraID", Type: "OIDC"},
+			},
+			Info:            &info,
+			CallerAccountId: fakeCallerAccountId,
+		},
+		EnrollAccount: &fakes.EnrollAccountAPI{
+			CallerAccountId: fakeCallerAccountId,
+		},
+		VersionHandler: &fakes.VersionHandlerAPI{
+			Versions: map[string]version_handler.Version{},
+		},
+		VersionHandlerV2: &fakes.VersionHandlerV2API{},
+	}
+
+	apis.local = localServices{
+		CentralCognito:   startFakeAPI(t, apis.Cognito),
+		EnrollAccount:    startFakeAPI(t, apis.EnrollAccount),
+		VersionHandler:   startFakeAPI(t, apis.VersionHandler),
+		VersionHandlerV2: startFakeAPI(t, apis.VersionHandlerV2),
+	}
+	info.Issuer = "http://" + apis.local.CentralCognito
+	info.JwksUrl = info.Issuer + "/.well-known/jwks.json"
+	info.OpenIdUrl = info.Issuer + "/.well-known/openid-configuration"
+	info.TokenEndpoint = info.Issuer + "/oauth2/token"
+	apis.local.UserPools = map[string]userPool{
+		"test": {UserPoolId: info.UserPoolId, Region: info.Region, AuthUrl: info.AuthUrl, Issuer: info.Issuer},
+	}
+
+	apis.CognitoClient = &central_cognito.Client{BaseUrl: apis.local.CentralCognito, HTTPClient: &http.Client{}}
+	apis.EnrollAccountClient = &enroll_account.Client{BaseUrl: apis.local.EnrollAccount, HTTPClient: &http.Client{}}
+
+	return apis
+}
+
+// startFakeAPI serves the fake until the test is done, and returns the host it listens on.
+func startFakeAPI(t *testing.T, api http.Handler) string {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+// providerFactories are the provider factories for tests running against the fakes.
+// The provider talks to the fakes over plain HTTP, without AWS credentials.
+func (apis *fakeAPIs) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"vy": providerserver.NewProtocol6WithError(&VyProvider{version: "test", local: &apis.local}),
+	}
+}
+
+// testFakePreCheck skips tests against the fakes when there is no Terraform CLI to run them with.
+// They run in a plain `go test` whenever Terraform is installed, without TF_ACC or AWS credentials.
+func testFakePreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("The Terraform CLI is not installed. Install it, or set TF_ACC_TERRAFORM_PATH, to run the tests against the fakes.")
+	}
+}
@@ -127,7 +127,7 @@ func (s FrontendArtifactDataSource) Read(ctx context.Context, request datasource
 	}
 
 	var version version_handler_v2.LambdaArtifact
-	err := s.client.ReadLambdaArtifact(
+	err := s.client.ReadLambdaArtifact(ctx,
 		state.GitHubRepositoryName.ValueString(),
 		"", // No ECR repository name for frontend artifacts
 		state.WorkingDirectory.ValueString(),
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &IdentityProvidersDataSource{}
+
+func NewIdentityProvidersDataSource() datasource.DataSource {
+	return &IdentityProvidersDataSource{}
+}
+
+type IdentityProvidersDataSource struct {
+	client *central_cognito.Client
+}
+
+type IdentityProvidersDataSourceModel struct {
+	Id                types.String           `tfsdk:"id"`
+	Type              types.String           `tfsdk:"type"`
+	Names             []string               `tfsdk:"names"`
+	IdentityProviders []identityProviderItem `tfsdk:"identity_providers"`
+}
+
+type identityProviderItem struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (i IdentityProvidersDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_identity_providers"
+}
+
+func (i IdentityProvidersDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "List the identity providers registered in the shared Cognito. " +
+			"Use the names in `supported_identity_providers` of `vy_app_client`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list identity providers of this type, e.g. `OIDC`, `SAML` or `COGNITO`",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of the identity providers, sorted",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"identity_providers": schema.ListNestedAttribute{
+				MarkdownDescription: "The identity providers, sorted by their name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the identity provider. `COGNITO` is the user directory of the pool itself.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the identity provider, e.g. `OIDC`, `SAML` or `COGNITO`",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (i *IdentityProvidersDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	i.client = configuration.CognitoClient
+}
+
+func (i IdentityProvidersDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state IdentityProvidersDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	providers, err := i.client.ListIdentityProviders(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to list identity providers",
+			fmt.Sprintf("Can't list identity providers from remote: %s", err.Error()),
+		)
+		return
+	}
+
+	slices.SortFunc(providers, func(a, b central_cognito.IdentityProvider) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	state.Id = types.StringValue("identity-providers")
+	state.Names = []string{}
+	state.IdentityProviders = []identityProviderItem{}
+
+	for _, provider := range providers {
+		if !state.Type.IsNull() && provider.Type != state.Type.ValueString() {
+			continue
+		}
+
+		state.Names = append(state.Names, provider.Name)
+		state.IdentityProviders = append(state.IdentityProviders, identityProviderItem{
+			Name: types.StringValue(provider.Name),
+			Type: types.StringValue(provider.Type),
+		})
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFakeIdentityProvidersDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "data.vy_identity_providers.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `data "vy_identity_providers" "this" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "names.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "names.0", "COGNITO"),
+					resource.TestCheckResourceAttr(expected_resource_name, "identity_providers.1.name", "EntraID"),
+					resource.TestCheckResourceAttr(expected_resource_name, "identity_providers.1.type", "OIDC"),
+				),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_identity_providers" "this" {
+	type = "OIDC"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "names.#", "1"),
+					resource.TestCheckResourceAttr(expected_resource_name, "names.0", "EntraID"),
+				),
+			},
+		},
+	})
+}
@@ -141,7 +141,7 @@ func (s LambdaArtifactDataSource) Read(ctx context.Context, request datasource.R
 	}
 
 	var version version_handler_v2.LambdaArtifact
-	err := s.client.ReadLambdaArtifact(
+	err := s.client.ReadLambdaArtifact(ctx,
 		state.GitHubRepositoryName.ValueString(),
 		state.ECRRepositoryName.ValueString(),
 		state.WorkingDirectory.ValueString(),
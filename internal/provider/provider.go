@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
 	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
 	"github.com/nsbno/terraform-provider-vy/internal/enroll_account"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
 	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
 	"github.com/nsbno/terraform-provider-vy/internal/version_handler_v2"
 
@@ -19,6 +24,16 @@ import (
 )
 
 var _ provider.Provider = &VyProvider{}
+var _ provider.ProviderWithEphemeralResources = &VyProvider{}
+
+const (
+	// defaultRequestTimeout limits a single request to one of our services.
+	defaultRequestTimeout = 30 * time.Second
+
+	// defaultResourceTimeout limits a whole resource operation, including retries.
+	// It can be changed per resource through the `timeouts` block.
+	defaultResourceTimeout = 5 * time.Minute
+)
 
 // VyProvider satisfies the tfsdk.Provider interface and usually is included
 // with all Resource and DataSource implementations.
@@ -28,24 +43,62 @@ type VyProvider struct {
 	// testing.
 	version string
 
+	// local points the services at local servers. It is only set by the tests.
+	local *localServices
+
 	config *VyProviderConfiguration
 }
 
+// localServices are the hosts of local servers that replace our services, over plain HTTP and without signing requests.
+// They are used to run the tests against the fake APIs. Services without a host are not replaced.
+type localServices struct {
+	CentralCognito   string
+	EnrollAccount    string
+	VersionHandler   string
+	VersionHandlerV2 string
+	// UserPools replace the known user pools, so their OpenID configuration can be served locally too.
+	UserPools map[string]userPool
+}
+
 type VyProviderConfiguration struct {
 	Environment            string
 	CognitoClient          *central_cognito.Client
 	EnrollAccountClient    *enroll_account.Client
 	VersionHandlerClient   *version_handler.Client
 	VersionHandlerClientV2 *version_handler_v2.Client
+
+	// userPools are the user pools of the environments that are known without asking the delegated Cognito.
+	userPools map[string]userPool
+	// plannedScopes are shared by the resources, so app clients can use scopes that are created in the same apply.
+	plannedScopes *plannedScopes
 }
 
 // VyProviderModel can be used to store data from the Terraform configuration.
 type VyProviderModel struct {
-	CentralCognitoBaseUrl        types.String `tfsdk:"central_cognito_base_url"`
-	EnrollAccountBaseUrl         types.String `tfsdk:"enroll_account_base_url"`
-	VersionHandlerV2BaseUrl      types.String `tfsdk:"version_handler_v2_base_url"` // For testing only
-	Environment                  types.String `tfsdk:"environment"`
-	DeploymentServiceEnvironment types.String `tfsdk:"deployment_service_environment"`
+	CentralCognitoBaseUrl        types.String               `tfsdk:"central_cognito_base_url"`
+	EnrollAccountBaseUrl         types.String               `tfsdk:"enroll_account_base_url"`
+	VersionHandlerV2BaseUrl      types.String               `tfsdk:"version_handler_v2_base_url"` // For testing only
+	Environment                  types.String               `tfsdk:"environment"`
+	DeploymentServiceEnvironment types.String               `tfsdk:"deployment_service_environment"`
+	Profile                      types.String               `tfsdk:"profile"`
+	Region                       types.String               `tfsdk:"region"`
+	SharedConfigFiles            types.List                 `tfsdk:"shared_config_files"`
+	AssumeRole                   *VyProviderAssumeRoleModel `tfsdk:"assume_role"`
+	Retry                        *VyProviderRetryModel      `tfsdk:"retry"`
+	RequestTimeout               types.String               `tfsdk:"request_timeout"`
+}
+
+type VyProviderAssumeRoleModel struct {
+	RoleArn     types.String `tfsdk:"role_arn"`
+	SessionName types.String `tfsdk:"session_name"`
+	ExternalId  types.String `tfsdk:"external_id"`
+	Duration    types.String `tfsdk:"duration"`
+}
+
+type VyProviderRetryModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	BaseDelay   types.String `tfsdk:"base_delay"`
+	MaxDelay    types.String `tfsdk:"max_delay"`
 }
 
 func (p VyProvider) Metadata(ctx context.Context, request provider.MetadataRequest, response *provider.MetadataResponse) {
@@ -78,6 +131,69 @@ func (p VyProvider) Schema(ctx context.Context, request provider.SchemaRequest,
 					"This should be left blank unless you're testing the deployment service.",
 				Optional: true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The AWS profile used to sign requests to Vy's services. " +
+					"Defaults to the standard AWS credential chain.",
+				Optional: true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region used to sign requests, and to assume `assume_role` in. Defaults to `eu-west-1`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long a single request to Vy's services may take before it is cancelled, e.g. `30s`. " +
+					"Applies to each retry separately. Defaults to `30s`.",
+				Optional: true,
+			},
+			"shared_config_files": schema.ListAttribute{
+				MarkdownDescription: "Paths to the AWS shared config files to look up `profile` in. " +
+					"Defaults to `~/.aws/config`.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"assume_role": schema.SingleNestedBlock{
+				MarkdownDescription: "A role to assume before signing requests. " +
+					"The identity of this role decides which AWS account is enrolled by `vy_deployment_account` and `vy_environment_account`.",
+				Attributes: map[string]schema.Attribute{
+					"role_arn": schema.StringAttribute{
+						MarkdownDescription: "The ARN of the role to assume.",
+						Optional:            true,
+					},
+					"session_name": schema.StringAttribute{
+						MarkdownDescription: "The session name to use when assuming the role. Defaults to `terraform-provider-vy`.",
+						Optional:            true,
+					},
+					"external_id": schema.StringAttribute{
+						MarkdownDescription: "The external ID to use when assuming the role.",
+						Optional:            true,
+					},
+					"duration": schema.StringAttribute{
+						MarkdownDescription: "How long the assumed role session lasts, e.g. `1h`. Defaults to `15m`.",
+						Optional:            true,
+					},
+				},
+			},
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "How requests to Vy's services are retried when they are throttled or fail with a transient error.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of attempts for a single request, including the first one. " +
+							"Set to `1` to disable retries. Defaults to `5`.",
+						Optional: true,
+					},
+					"base_delay": schema.StringAttribute{
+						MarkdownDescription: "The base delay for the exponential backoff between attempts, e.g. `500ms`. `0s` retries without waiting. Defaults to `500ms`.",
+						Optional:            true,
+					},
+					"max_delay": schema.StringAttribute{
+						MarkdownDescription: "The maximum delay between two attempts, e.g. `20s`. " +
+							"Also caps delays requested by the service through `Retry-After`. Defaults to `20s`.",
+						Optional: true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -90,6 +206,98 @@ func createUrlFromEnvironment(baseUrl string, urlPrefix string, environment stri
 	}
 }
 
+// retryConfigFromModel fills in the retry settings from the provider block on top of the defaults.
+func retryConfigFromModel(model *VyProviderRetryModel, diags *diag.Diagnostics) transport.RetryConfig {
+	retry := transport.DefaultRetryConfig()
+	if model == nil {
+		return retry
+	}
+
+	if !model.MaxAttempts.IsNull() {
+		if model.MaxAttempts.ValueInt64() < 1 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("max_attempts"),
+				"Invalid retry attempts",
+				fmt.Sprintf("max_attempts must be at least 1. Got: %d.", model.MaxAttempts.ValueInt64()),
+			)
+		}
+		retry.MaxAttempts = int(model.MaxAttempts.ValueInt64())
+	}
+
+	parseDelay := func(value types.String, name string, target *time.Duration) {
+		if value.IsNull() {
+			return
+		}
+
+		delay, err := time.ParseDuration(value.ValueString())
+		if err != nil || delay < 0 {
+			diags.AddAttributeError(
+				path.Root("retry").AtName(name),
+				"Invalid retry delay",
+				fmt.Sprintf("%s must be a non-negative duration like '500ms' or '20s'. Got: '%s'.", name, value.ValueString()),
+			)
+			return
+		}
+		*target = delay
+	}
+
+	parseDelay(model.BaseDelay, "base_delay", &retry.BaseDelay)
+	parseDelay(model.MaxDelay, "max_delay", &retry.MaxDelay)
+
+	return retry
+}
+
+// signerConfigFromModel selects the AWS identity used to sign requests from the provider block.
+func signerConfigFromModel(ctx context.Context, data VyProviderModel, diags *diag.Diagnostics) aws_auth.Config {
+	signerConfig := aws_auth.Config{
+		Profile: data.Profile.ValueString(),
+		Region:  data.Region.ValueString(),
+	}
+
+	if data.SharedConfigFiles.IsUnknown() {
+		diags.AddAttributeError(
+			path.Root("shared_config_files"),
+			"Unknown shared config files",
+			"shared_config_files must be known when the provider is configured, so it can't depend on values that are only known after an apply.",
+		)
+		return signerConfig
+	}
+	diags.Append(data.SharedConfigFiles.ElementsAs(ctx, &signerConfig.SharedConfigFiles, false)...)
+
+	if data.AssumeRole == nil {
+		return signerConfig
+	}
+
+	if data.AssumeRole.RoleArn.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("assume_role").AtName("role_arn"),
+			"Missing role ARN",
+			"role_arn must be set when using assume_role.",
+		)
+		return signerConfig
+	}
+
+	signerConfig.AssumeRole = &aws_auth.AssumeRoleConfig{
+		RoleArn:     data.AssumeRole.RoleArn.ValueString(),
+		SessionName: data.AssumeRole.SessionName.ValueString(),
+		ExternalId:  data.AssumeRole.ExternalId.ValueString(),
+	}
+
+	if !data.AssumeRole.Duration.IsNull() {
+		duration, err := time.ParseDuration(data.AssumeRole.Duration.ValueString())
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root("assume_role").AtName("duration"),
+				"Invalid assume role duration",
+				fmt.Sprintf("duration must be a positive duration like '15m' or '1h'. Got: '%s'.", data.AssumeRole.Duration.ValueString()),
+			)
+		}
+		signerConfig.AssumeRole.Duration = duration
+	}
+
+	return signerConfig
+}
+
 func (p VyProvider) Configure(ctx context.Context, request provider.ConfigureRequest, response *provider.ConfigureResponse) {
 	var data VyProviderModel
 
@@ -118,16 +326,59 @@ func (p VyProvider) Configure(ctx context.Context, request provider.ConfigureReq
 		deploymentServiceEnvironment = data.DeploymentServiceEnvironment.ValueString()
 	}
 
+	retry := retryConfigFromModel(data.Retry, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if !data.RequestTimeout.IsNull() {
+		timeout, err := time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request timeout",
+				fmt.Sprintf("request_timeout must be a positive duration like '30s' or '1m'. Got: '%s'.", data.RequestTimeout.ValueString()),
+			)
+			return
+		}
+		requestTimeout = timeout
+	}
+
+	signerConfig := signerConfigFromModel(ctx, data, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// The signer is shared by all clients, so credentials are resolved once and cached.
+	signer, err := aws_auth.LoadSigner(ctx, signerConfig)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to load AWS configuration",
+			fmt.Sprintf("The AWS configuration used to sign requests could not be loaded: %s", err.Error()),
+		)
+		return
+	}
+
 	cognitoClient := &central_cognito.Client{
-		BaseUrl: createUrlFromEnvironment(cognitoDomain, "delegated", data.Environment.ValueString()),
+		BaseUrl:        createUrlFromEnvironment(cognitoDomain, "delegated", data.Environment.ValueString()),
+		Signer:         signer,
+		Retry:          retry,
+		RequestTimeout: requestTimeout,
 	}
 
 	enrollClient := &enroll_account.Client{
-		BaseUrl: createUrlFromEnvironment(enrollAccountDomain, "enroll", deploymentServiceEnvironment),
+		BaseUrl:        createUrlFromEnvironment(enrollAccountDomain, "enroll", deploymentServiceEnvironment),
+		Signer:         signer,
+		Retry:          retry,
+		RequestTimeout: requestTimeout,
 	}
 
 	versionClient := &version_handler.Client{
-		BaseUrl: createUrlFromEnvironment(enrollAccountDomain, "version-handler", deploymentServiceEnvironment),
+		BaseUrl:        createUrlFromEnvironment(enrollAccountDomain, "version-handler", deploymentServiceEnvironment),
+		Signer:         signer,
+		Retry:          retry,
+		RequestTimeout: requestTimeout,
 	}
 
 	// Configure version handler v2 client with optional test URL
@@ -135,38 +386,92 @@ func (p VyProvider) Configure(ctx context.Context, request provider.ConfigureReq
 	if !data.VersionHandlerV2BaseUrl.IsNull() {
 		// Test
 		versionClientV2 = &version_handler_v2.Client{
-			BaseUrl:    data.VersionHandlerV2BaseUrl.ValueString(),
-			HTTPClient: &http.Client{},
+			BaseUrl:        data.VersionHandlerV2BaseUrl.ValueString(),
+			HTTPClient:     &http.Client{},
+			Retry:          retry,
+			RequestTimeout: requestTimeout,
 		}
 	} else {
 		// Production: use default URL with AWS signing
 		versionClientV2 = &version_handler_v2.Client{
-			BaseUrl: createUrlFromEnvironment(enrollAccountDomain, "version-handler", deploymentServiceEnvironment),
+			BaseUrl:        createUrlFromEnvironment(enrollAccountDomain, "version-handler", deploymentServiceEnvironment),
+			Signer:         signer,
+			Retry:          retry,
+			RequestTimeout: requestTimeout,
+		}
+	}
+
+	if local := p.local; local != nil {
+		if local.CentralCognito != "" {
+			cognitoClient.BaseUrl = local.CentralCognito
+			cognitoClient.HTTPClient = &http.Client{}
+		}
+		if local.EnrollAccount != "" {
+			enrollClient.BaseUrl = local.EnrollAccount
+			enrollClient.HTTPClient = &http.Client{}
+		}
+		if local.VersionHandler != "" {
+			versionClient.BaseUrl = local.VersionHandler
+			versionClient.HTTPClient = &http.Client{}
+		}
+		if local.VersionHandlerV2 != "" && data.VersionHandlerV2BaseUrl.IsNull() {
+			versionClientV2.BaseUrl = local.VersionHandlerV2
+			versionClientV2.HTTPClient = &http.Client{}
+		}
+	}
+
+	// The signer only resolves credentials for the first request, which would report missing credentials on every resource.
+	remote := cognitoClient.HTTPClient == nil || enrollClient.HTTPClient == nil ||
+		versionClient.HTTPClient == nil || versionClientV2.HTTPClient == nil
+	if remote {
+		if err := signer.Retrieve(ctx); err != nil {
+			response.Diagnostics.AddError(
+				"Unable to retrieve AWS credentials",
+				fmt.Sprintf("The AWS credentials used to sign requests could not be retrieved: %s", err.Error()),
+			)
+			return
 		}
 	}
 
+	userPools := knownUserPools
+	if p.local != nil && p.local.UserPools != nil {
+		userPools = p.local.UserPools
+	}
+
 	config := &VyProviderConfiguration{
 		Environment:            data.Environment.ValueString(),
 		CognitoClient:          cognitoClient,
 		EnrollAccountClient:    enrollClient,
 		VersionHandlerClient:   versionClient,
 		VersionHandlerClientV2: versionClientV2,
+		userPools:              userPools,
+		plannedScopes:          &plannedScopes{},
 	}
 
 	p.config = config
 	response.ResourceData = config
 	response.DataSourceData = config
+	response.EphemeralResourceData = config
 }
 
 func (p VyProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewResourceServerResource,
+		NewResourceServerScopeResource,
+		NewScopeGrantResource,
 		NewAppClientResource,
 		NewDeploymentAccountResource,
 		NewEnvironmentAccountResource,
 	}
 }
 
+func (p VyProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAppClientSecretEphemeralResource,
+		NewCognitoAccessTokenEphemeralResource,
+	}
+}
+
 func (p VyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCognitoInfoDataSource,
@@ -174,6 +479,14 @@ func (p VyProvider) DataSources(ctx context.Context) []func() datasource.DataSou
 		NewECSImageDataSource,
 		NewLambdaArtifactDataSource,
 		NewFrontendArtifactDataSource,
+		NewResourceServerDataSource,
+		NewAppClientDataSource,
+		NewResourceServersDataSource,
+		NewAppClientsDataSource,
+		NewCognitoJwksDataSource,
+		NewIdentityProvidersDataSource,
+		NewScopeGrantRequestsDataSource,
+		NewScopeConsumersDataSource,
 	}
 }
 
@@ -1,9 +1,22 @@
 package provider
 
 import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
-	"testing"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
 )
 
 var testAccProvider, _ = convertProviderType(New("test")())
@@ -30,3 +43,144 @@ func testAccPreCheck(t *testing.T) {
 	// function.
 
 }
+
+func TestConfigure_RetrievesCredentialsForRemoteServices(t *testing.T) {
+	ctx := context.Background()
+
+	var schema provider.SchemaResponse
+	VyProvider{}.Schema(ctx, provider.SchemaRequest{}, &schema)
+
+	tests := []struct {
+		name        string
+		accessKeyId string
+		local       bool
+		wantError   bool
+	}{
+		{name: "remote with credentials", accessKeyId: "AKIDEXAMPLE"},
+		{name: "remote without credentials", wantError: true},
+		{name: "local without credentials", local: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("AWS_ACCESS_KEY_ID", tt.accessKeyId)
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+			t.Setenv("AWS_SESSION_TOKEN", "")
+			t.Setenv("AWS_PROFILE", "")
+			t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+			t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+			t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+			t.Setenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "")
+			t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", "")
+			t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+			p := VyProvider{}
+			if tt.local {
+				p.local = &localServices{
+					CentralCognito:   "localhost:1",
+					EnrollAccount:    "localhost:1",
+					VersionHandler:   "localhost:1",
+					VersionHandlerV2: "localhost:1",
+				}
+			}
+
+			config := tfsdk.State{
+				Schema: schema.Schema,
+				Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+			}
+			diags := config.Set(ctx, &VyProviderModel{
+				CentralCognitoBaseUrl:        types.StringNull(),
+				EnrollAccountBaseUrl:         types.StringNull(),
+				VersionHandlerV2BaseUrl:      types.StringNull(),
+				Environment:                  types.StringValue("test"),
+				DeploymentServiceEnvironment: types.StringNull(),
+				Profile:                      types.StringNull(),
+				Region:                       types.StringNull(),
+				SharedConfigFiles:            types.ListNull(types.StringType),
+				RequestTimeout:               types.StringNull(),
+			})
+			if diags.HasError() {
+				t.Fatalf("could not set config: %v", diags)
+			}
+
+			var response provider.ConfigureResponse
+			p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}, &response)
+
+			if response.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("diagnostics = %v, want error: %v", response.Diagnostics, tt.wantError)
+			}
+			if tt.wantError && response.Diagnostics.Errors()[0].Summary() != "Unable to retrieve AWS credentials" {
+				t.Errorf("expected the credentials to be reported, got: %v", response.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestSignerConfigFromModel_SharedConfigFiles(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		files     types.List
+		wantFiles []string
+		wantError bool
+	}{
+		{name: "not set", files: types.ListNull(types.StringType)},
+		{name: "known", files: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("/tmp/config")}), wantFiles: []string{"/tmp/config"}},
+		{name: "unknown until applied", files: types.ListUnknown(types.StringType), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			config := signerConfigFromModel(ctx, VyProviderModel{SharedConfigFiles: tt.files}, &diags)
+
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("diagnostics = %v, want error: %v", diags, tt.wantError)
+			}
+			if !reflect.DeepEqual(config.SharedConfigFiles, tt.wantFiles) {
+				t.Errorf("shared config files = %v, want %v", config.SharedConfigFiles, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestRetryConfigFromModel_Delays(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseDelay     types.String
+		wantBaseDelay time.Duration
+		wantError     bool
+	}{
+		{name: "not set", baseDelay: types.StringNull(), wantBaseDelay: transport.DefaultRetryConfig().BaseDelay},
+		{name: "positive", baseDelay: types.StringValue("250ms"), wantBaseDelay: 250 * time.Millisecond},
+		{name: "zero", baseDelay: types.StringValue("0s"), wantBaseDelay: 0},
+		{name: "negative", baseDelay: types.StringValue("-1s"), wantError: true},
+		{name: "not a duration", baseDelay: types.StringValue("soon"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			retry := retryConfigFromModel(&VyProviderRetryModel{
+				MaxAttempts: types.Int64Null(),
+				BaseDelay:   tt.baseDelay,
+				MaxDelay:    types.StringNull(),
+			}, &diags)
+
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("diagnostics = %v, want error: %v", diags, tt.wantError)
+			}
+			if tt.wantError {
+				if !strings.Contains(diags.Errors()[0].Detail(), "non-negative duration") {
+					t.Errorf("expected the error to ask for a non-negative duration, got: %v", diags)
+				}
+				return
+			}
+			if retry.BaseDelay != tt.wantBaseDelay {
+				t.Errorf("base delay = %v, want %v", retry.BaseDelay, tt.wantBaseDelay)
+			}
+		})
+	}
+}
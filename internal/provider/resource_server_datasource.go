@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &ResourceServerDataSource{}
+
+func NewResourceServerDataSource() datasource.DataSource {
+	return &ResourceServerDataSource{}
+}
+
+type ResourceServerDataSource struct {
+	client *central_cognito.Client
+}
+
+type ResourceServerDataSourceModel struct {
+	Id         types.String `tfsdk:"id"`
+	Identifier types.String `tfsdk:"identifier"`
+	Name       types.String `tfsdk:"name"`
+	Scopes     []scope      `tfsdk:"scopes"`
+}
+
+func (r ResourceServerDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource_server"
+}
+
+func (r ResourceServerDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Get a resource server owned by someone else, e.g. to give your app client access to its scopes. " +
+			"Use the `vy_resource_server` resource for resource servers you own.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"identifier": schema.StringAttribute{
+				MarkdownDescription: "The identity of the resource server",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the resource server",
+				Computed:            true,
+			},
+			"scopes": schema.SetNestedAttribute{
+				MarkdownDescription: "Scopes of the resource server",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the scope",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "A description of what the scope is for",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ResourceServerDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+}
+
+func (r ResourceServerDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state ResourceServerDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var server central_cognito.ResourceServer
+	err := r.client.ReadResourceServer(ctx, state.Identifier.ValueString(), &server)
+	if api_errors.IsNotFound(err) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("identifier"),
+			"Resource server not found",
+			fmt.Sprintf("There is no resource server with the identifier %s.", state.Identifier.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read resource server",
+			fmt.Sprintf("Can't read resource server %s from remote: %s", state.Identifier.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(server.Identifier)
+	state.Identifier = types.StringValue(server.Identifier)
+	state.Name = types.StringValue(server.Name)
+
+	state.Scopes = []scope{}
+	for _, domainScope := range server.Scopes {
+		state.Scopes = append(state.Scopes, scope{
+			Name:        types.StringValue(domainScope.Name),
+			Description: types.StringValue(domainScope.Description),
+		})
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
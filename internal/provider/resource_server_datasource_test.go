@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeResourceServerDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["other-team.fake.io"] = central_cognito.ResourceServer{
+		Identifier: "other-team.fake.io",
+		Name:       "other team",
+		Scopes: []central_cognito.Scope{
+			{Name: "read", Description: "Read things"},
+			{Name: "write", Description: "Write things"},
+		},
+	}
+	expected_resource_name := "data.vy_resource_server.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_resource_server" "this" {
+	identifier = "other-team.fake.io"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "name", "other team"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(expected_resource_name, "scopes.*", map[string]string{
+						"name":        "read",
+						"description": "Read things",
+					}),
+				),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_resource_server" "this" {
+	identifier = "does-not-exist.fake.io"
+}
+`,
+				ExpectError: regexp.MustCompile("Resource server not found"),
+			},
+		},
+	})
+}
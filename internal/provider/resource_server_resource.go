@@ -3,23 +3,34 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
 )
 
+var _ resource.ResourceWithModifyPlan = &ResourceServerResource{}
+var _ resource.ResourceWithConfigValidators = &ResourceServerResource{}
+
 func NewResourceServerResource() resource.Resource {
 	return &ResourceServerResource{}
 }
 
 type ResourceServerResource struct {
 	client *central_cognito.Client
+	// plannedScopes tells app clients planned later which scopes this resource server creates.
+	plannedScopes *plannedScopes
 }
 
 type ResourceServerResourceModel struct {
@@ -27,6 +38,8 @@ type ResourceServerResourceModel struct {
 	Identifier types.String `tfsdk:"identifier"`
 	Name       types.String `tfsdk:"name"`
 	Scopes     []scope      `tfsdk:"scopes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type scope struct {
@@ -43,7 +56,9 @@ func (r ResourceServerResource) Schema(ctx context.Context, request resource.Sch
 		MarkdownDescription: "A resource server is an integration between a user pool and an API. " +
 			"Each resource server has custom scopes that you must activate in your app client. " +
 			"When you configure a resource server, your app can generate access tokens with OAuth scopes that " +
-			"authorize read and write operations to your API server.",
+			"authorize read and write operations to your API server.\n\n" +
+			"Only the scopes declared in `scopes` are managed by this resource. " +
+			"Scopes added with `vy_resource_server_scope` are left alone.",
 
 		Attributes: map[string]schema.Attribute{
 			// id is required by the SDKv2 testing framework.
@@ -60,28 +75,95 @@ func (r ResourceServerResource) Schema(ctx context.Context, request resource.Sch
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					resourceServerIdentifierValidator{},
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of this resource server",
 				Required:            true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
 			},
 			"scopes": schema.SetNestedAttribute{
-				MarkdownDescription: "Scopes for this resource server",
-				Optional:            true,
+				MarkdownDescription: "Scopes for this resource server. Scopes that are not declared here, e.g. those added by `vy_resource_server_scope`, are ignored. " +
+					"Importing leaves the scopes out, and the scopes that are declared here are taken over by the next apply.",
+				Optional: true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
 							MarkdownDescription: "A name for this scope",
 							Required:            true,
+							Validators: []validator.String{
+								scopeNameValidator{},
+							},
 						},
 						"description": schema.StringAttribute{
 							MarkdownDescription: "A description of what this scope is for",
 							Required:            true,
+							Validators: []validator.String{
+								notBlankValidator{},
+							},
 						},
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, updatableResourceTimeouts),
+		},
+	}
+}
+
+var _ resource.ConfigValidator = uniqueScopeNamesValidator{}
+
+// uniqueScopeNamesValidator catches scopes with the same name, which the set allows as long as their descriptions differ.
+type uniqueScopeNamesValidator struct{}
+
+func (t uniqueScopeNamesValidator) Description(ctx context.Context) string {
+	return "each scope must have a unique name"
+}
+
+func (t uniqueScopeNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return "each scope must have a unique `name`"
+}
+
+func (t uniqueScopeNamesValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var scopes types.Set
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("scopes"), &scopes)...)
+	if response.Diagnostics.HasError() || scopes.IsNull() || scopes.IsUnknown() {
+		return
+	}
+
+	var configured []scope
+	response.Diagnostics.Append(scopes.ElementsAs(ctx, &configured, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for _, config_scope := range configured {
+		if config_scope.Name.IsUnknown() || config_scope.Name.IsNull() {
+			continue
+		}
+
+		name := config_scope.Name.ValueString()
+		if seen[name] {
+			response.Diagnostics.AddAttributeError(
+				path.Root("scopes"),
+				"Duplicate scope",
+				fmt.Sprintf("The scope '%s' is declared more than once.", name),
+			)
+		}
+		seen[name] = true
+	}
+}
+
+// ConfigValidators checks what the attribute validators can't see on their own.
+func (r ResourceServerResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		uniqueScopeNamesValidator{},
 	}
 }
 
@@ -103,6 +185,7 @@ func (c *ResourceServerResource) Configure(ctx context.Context, request resource
 	}
 
 	c.client = configuration.CognitoClient
+	c.plannedScopes = configuration.plannedScopes
 }
 
 func stateToDomain(state ResourceServerResourceModel, domain *central_cognito.ResourceServer) {
@@ -125,6 +208,9 @@ func domainToState(domain central_cognito.ResourceServer, state *ResourceServerR
 	state.Identifier = types.StringValue(domain.Identifier)
 	state.Name = types.StringValue(domain.Name)
 
+	// An empty list of scopes in the configuration has to stay empty, not become null.
+	declaredEmpty := state.Scopes != nil && len(state.Scopes) == 0
+
 	state.Scopes = []scope{}
 	for _, domain_scope := range domain.Scopes {
 		state_scope := scope{}
@@ -135,12 +221,30 @@ func domainToState(domain central_cognito.ResourceServer, state *ResourceServerR
 		state.Scopes = append(state.Scopes, state_scope)
 	}
 
-	if len(state.Scopes) == 0 {
+	if len(state.Scopes) == 0 && !declaredEmpty {
 		// Terraform thinks this has changed if we keep the scopes as an empty list.
 		state.Scopes = nil
 	}
 }
 
+// managedScopes returns the scopes of the remote resource server that are declared in state.
+// The other scopes are managed by someone else, e.g. through `vy_resource_server_scope`.
+func managedScopes(remote []central_cognito.Scope, state []scope) []central_cognito.Scope {
+	declared := map[string]bool{}
+	for _, state_scope := range state {
+		declared[state_scope.Name.ValueString()] = true
+	}
+
+	managed := []central_cognito.Scope{}
+	for _, remote_scope := range remote {
+		if declared[remote_scope.Name] {
+			managed = append(managed, remote_scope)
+		}
+	}
+
+	return managed
+}
+
 func (r ResourceServerResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var data ResourceServerResourceModel
 
@@ -151,12 +255,21 @@ func (r ResourceServerResource) Create(ctx context.Context, request resource.Cre
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	data.Id = data.Identifier
 
 	var server central_cognito.ResourceServer
 	stateToDomain(data, &server)
 
-	err := r.client.CreateResourceServer(server)
+	created, err := r.client.CreateResourceServer(ctx, server)
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -168,6 +281,9 @@ func (r ResourceServerResource) Create(ctx context.Context, request resource.Cre
 		return
 	}
 
+	created.Scopes = managedScopes(created.Scopes, data.Scopes)
+	domainToState(*created, &data)
+
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
 }
@@ -182,8 +298,24 @@ func (r ResourceServerResource) Read(ctx context.Context, request resource.ReadR
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	var server central_cognito.ResourceServer
-	err := r.client.ReadResourceServer(data.Identifier.ValueString(), &server)
+	err := r.client.ReadResourceServer(ctx, data.Identifier.ValueString(), &server)
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "Resource server no longer exists in remote, removing it from state", map[string]interface{}{
+			"identifier": data.Identifier.ValueString(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -194,6 +326,7 @@ func (r ResourceServerResource) Read(ctx context.Context, request resource.ReadR
 		return
 	}
 
+	server.Scopes = managedScopes(server.Scopes, data.Scopes)
 	domainToState(server, &data)
 
 	diags = response.State.Set(ctx, &data)
@@ -206,19 +339,36 @@ func (r ResourceServerResource) Update(ctx context.Context, request resource.Upd
 	diags := request.Plan.Get(ctx, &data)
 	response.Diagnostics.Append(diags...)
 
+	var prior ResourceServerResourceModel
+
+	diags = request.State.Get(ctx, &prior)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	data.Id = data.Identifier
 
 	var server central_cognito.ResourceServer
 	stateToDomain(data, &server)
 
-	err := r.client.UpdateResourceServer(central_cognito.ResourceServerUpdateRequest{
+	var priorServer central_cognito.ResourceServer
+	stateToDomain(prior, &priorServer)
+
+	// The scopes are left out, so scopes that are managed elsewhere are kept.
+	updated, err := r.client.UpdateResourceServer(ctx, central_cognito.ResourceServerUpdateRequest{
 		Identifier: server.Identifier,
 		Name:       server.Name,
-		Scopes:     server.Scopes,
 	})
 	if err != nil {
 		diags = diag.Diagnostics{}
@@ -231,10 +381,203 @@ func (r ResourceServerResource) Update(ctx context.Context, request resource.Upd
 		return
 	}
 
+	err = r.updateScopes(ctx, server.Identifier, priorServer.Scopes, server.Scopes)
+	if err != nil {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Unable to update resource server",
+			"Can't update the scopes of resource server "+data.Identifier.String()+": "+err.Error(),
+		)
+		response.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	// The scopes were changed one at a time after the update, so they are read again.
+	var stored central_cognito.ResourceServer
+	err = r.client.ReadResourceServer(ctx, updated.Identifier, &stored)
+	if err != nil {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Unable to read resource server",
+			"Can't read resource server "+data.Identifier.String()+" from remote after the update: "+err.Error(),
+		)
+		response.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	updated.Scopes = managedScopes(stored.Scopes, data.Scopes)
+	domainToState(*updated, &data)
+
 	diags = response.State.Set(ctx, &data)
 	response.Diagnostics.Append(diags...)
 }
 
+// updateScopes changes the scopes of the resource server from the prior to the planned ones, one scope at a time.
+func (r ResourceServerResource) updateScopes(ctx context.Context, identifier string, prior []central_cognito.Scope, planned []central_cognito.Scope) error {
+	priorByName := map[string]central_cognito.Scope{}
+	for _, prior_scope := range prior {
+		priorByName[prior_scope.Name] = prior_scope
+	}
+
+	plannedNames := map[string]bool{}
+	for _, planned_scope := range planned {
+		plannedNames[planned_scope.Name] = true
+	}
+
+	for _, prior_scope := range prior {
+		if plannedNames[prior_scope.Name] {
+			continue
+		}
+
+		err := r.client.DeleteResourceServerScope(ctx, identifier, prior_scope.Name)
+		if err != nil && !api_errors.IsNotFound(err) {
+			return fmt.Errorf("could not remove scope %s: %w", prior_scope.Name, err)
+		}
+	}
+
+	for _, planned_scope := range planned {
+		prior_scope, exists := priorByName[planned_scope.Name]
+
+		var err error
+		switch {
+		case !exists:
+			err = r.client.CreateResourceServerScope(ctx, identifier, planned_scope)
+			// The scope exists, but isn't in state yet, e.g. after an import. It is taken over as it is declared.
+			if api_errors.IsConflict(err) {
+				err = r.client.UpdateResourceServerScope(ctx, identifier, planned_scope)
+			}
+		case prior_scope.Description != planned_scope.Description:
+			err = r.client.UpdateResourceServerScope(ctx, identifier, planned_scope)
+		}
+		if err != nil {
+			return fmt.Errorf("could not set scope %s: %w", planned_scope.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ModifyPlan warns when scopes that app clients still hold are removed, as those app clients will lose access.
+func (r ResourceServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.recordPlannedScopes(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing is removed when the resource server is created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state ResourceServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removed, known := r.removedScopes(ctx, req, resp, state)
+	if resp.Diagnostics.HasError() || !known || len(removed) == 0 {
+		return
+	}
+
+	consumers, err := r.client.ListScopeConsumers(ctx, state.Identifier.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("scopes"),
+			"Unable to check scope consumers",
+			fmt.Sprintf("Can't list the app clients holding the removed scopes from remote: %s", err.Error()),
+		)
+		return
+	}
+
+	byScope := consumersByScope(consumers)
+
+	var lines []string
+	for _, name := range removed {
+		for _, consumer := range byScope[name] {
+			lines = append(lines, fmt.Sprintf("  - %s/%s is held by %s (%s, account %s)",
+				state.Identifier.ValueString(), name, consumer.AppClientName, consumer.AppClientType, consumer.AccountId))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("scopes"),
+		"Removed scopes are still in use",
+		fmt.Sprintf("These app clients lose access when the scopes are removed:\n%s", strings.Join(lines, "\n")),
+	)
+}
+
+// recordPlannedScopes records the scopes in the plan, so app clients that are planned later can use them.
+func (r ResourceServerResource) recordPlannedScopes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is created when the resource server is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var identifier types.String
+	var scopes types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("identifier"), &identifier)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("scopes"), &scopes)...)
+	if resp.Diagnostics.HasError() || identifier.IsUnknown() || scopes.IsNull() || scopes.IsUnknown() {
+		return
+	}
+
+	var plannedScopes []scope
+	resp.Diagnostics.Append(scopes.ElementsAs(ctx, &plannedScopes, false)...)
+	for _, planned_scope := range plannedScopes {
+		if !planned_scope.Name.IsUnknown() {
+			r.plannedScopes.add(identifier.ValueString() + "/" + planned_scope.Name.ValueString())
+		}
+	}
+}
+
+// removedScopes returns the names of the scopes in state that the plan removes, sorted.
+// It is not known which are removed while the planned scopes are unknown.
+func (r ResourceServerResource) removedScopes(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, state ResourceServerResourceModel) ([]string, bool) {
+	planned := map[string]bool{}
+
+	// When destroyed, or replaced because of a new identifier, every scope is removed.
+	if !req.Plan.Raw.IsNull() {
+		var identifier types.String
+		var scopes types.Set
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("identifier"), &identifier)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("scopes"), &scopes)...)
+		if resp.Diagnostics.HasError() || identifier.IsUnknown() || scopes.IsUnknown() {
+			return nil, false
+		}
+
+		if identifier.Equal(state.Identifier) && !scopes.IsNull() {
+			var plannedScopes []scope
+			resp.Diagnostics.Append(scopes.ElementsAs(ctx, &plannedScopes, false)...)
+			if resp.Diagnostics.HasError() {
+				return nil, false
+			}
+
+			for _, planned_scope := range plannedScopes {
+				if planned_scope.Name.IsUnknown() {
+					return nil, false
+				}
+				planned[planned_scope.Name.ValueString()] = true
+			}
+		}
+	}
+
+	removed := []string{}
+	for _, state_scope := range state.Scopes {
+		if !planned[state_scope.Name.ValueString()] {
+			removed = append(removed, state_scope.Name.ValueString())
+		}
+	}
+	slices.Sort(removed)
+
+	return removed, true
+}
+
 func (r ResourceServerResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var data ResourceServerResourceModel
 
@@ -245,7 +588,16 @@ func (r ResourceServerResource) Delete(ctx context.Context, request resource.Del
 		return
 	}
 
-	err := r.client.DeleteResourceServer(data.Identifier.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteResourceServer(ctx, data.Identifier.ValueString())
 	if err != nil {
 		diags = diag.Diagnostics{}
 		diags.AddError(
@@ -269,9 +621,9 @@ func (r ResourceServerResource) Delete(ctx context.Context, request resource.Del
 func (r ResourceServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var importedResourceServer central_cognito.ResourceServer
 
-	err := r.client.ReadResourceServer(req.ID, &importedResourceServer)
+	err := r.client.ReadResourceServer(ctx, req.ID, &importedResourceServer)
 	if err != nil {
-		err = r.client.ImportResourceServer(req.ID, &importedResourceServer)
+		err = r.client.ImportResourceServer(ctx, req.ID, &importedResourceServer)
 
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -286,7 +638,12 @@ func (r ResourceServerResource) ImportState(ctx context.Context, req resource.Im
 		}
 	}
 
-	var resourceServerData ResourceServerResourceModel
+	// The remote can't tell which scopes are declared here and which are managed by `vy_resource_server_scope`.
+	// No scopes are imported, so later updates don't delete scopes that are managed elsewhere.
+	// The scopes that are declared here are taken over by the next apply.
+	importedResourceServer.Scopes = nil
+
+	resourceServerData := ResourceServerResourceModel{Timeouts: nullTimeouts(updatableResourceTimeouts)}
 	domainToState(importedResourceServer, &resourceServerData)
 
 	resp.State.Set(ctx, &resourceServerData)
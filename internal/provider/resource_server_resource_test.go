@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"context"
+	"reflect"
+	"strings"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
 )
 
 const testAccResourceServer_WithoutScopes = testAcc_ProviderConfig + `
@@ -64,3 +72,436 @@ func TestAccResourceServer_WithScopes(t *testing.T) {
 		},
 	})
 }
+
+const testAccResourceServer_WithTimeouts = testAcc_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "withtimeouts.acceptancetest.io"
+	name = "some service"
+
+	timeouts {
+		create = "2m"
+		delete = "1m"
+	}
+}
+`
+
+func TestAccResourceServer_WithTimeouts(t *testing.T) {
+	expected_resource_name := "vy_resource_server.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceServer_WithTimeouts,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "timeouts.create", "2m"),
+					resource.TestCheckResourceAttr(expected_resource_name, "timeouts.delete", "1m"),
+				),
+			},
+		},
+	})
+}
+
+const testFakeResourceServer_Create = testFake_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "https://fake.vydev.io/demo"
+	name = "demo"
+
+	scopes = [
+		{
+			name = "read"
+			description = "Allows for reading of stuff"
+		}
+	]
+}
+`
+
+const testFakeResourceServer_Update = testFake_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "https://fake.vydev.io/demo"
+	name = "renamed demo"
+
+	scopes = [
+		{
+			name = "read"
+			description = "Allows for reading of stuff"
+		},
+		{
+			name = "modify"
+			description = "Modify stuff"
+		}
+	]
+}
+`
+
+func TestFakeResourceServer_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_resource_server.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeResourceServer_Create,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", "https://fake.vydev.io/demo"),
+					resource.TestCheckResourceAttr(expected_resource_name, "name", "demo"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "1"),
+				),
+			},
+			{
+				Config: testFakeResourceServer_Update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "name", "renamed demo"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "2"),
+				),
+			},
+			{
+				ResourceName:      expected_resource_name,
+				ImportState:       true,
+				ImportStateId:     "https://fake.vydev.io/demo",
+				ImportStateVerify: true,
+				// Scopes are not imported, as they may be managed by vy_resource_server_scope.
+				ImportStateVerifyIgnore: []string{"scopes"},
+			},
+			{
+				// The resource server is deleted outside of Terraform, so it should be planned to be created again.
+				PreConfig: func() {
+					if err := apis.CognitoClient.DeleteResourceServer(context.Background(), "https://fake.vydev.io/demo"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeResourceServer_Update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestManagedScopes_KeepsOnlyDeclaredScopes(t *testing.T) {
+	remote := []central_cognito.Scope{
+		{Name: "read", Description: "Read"},
+		{Name: "write", Description: "Added by vy_resource_server_scope"},
+	}
+	declared := []scope{{Name: types.StringValue("read"), Description: types.StringValue("Read")}}
+
+	managed := managedScopes(remote, declared)
+
+	if len(managed) != 1 || managed[0].Name != "read" {
+		t.Errorf("expected only the declared scope, got %v", managed)
+	}
+}
+
+// resourceServerPlan builds a state or plan of the resource server, with the given scopes.
+func resourceServerPlan(t *testing.T, schema fwresource.SchemaResponse, names ...string) tfsdk.Plan {
+	t.Helper()
+
+	model := ResourceServerResourceModel{
+		Id:         types.StringValue("https://fake.vydev.io/trains"),
+		Identifier: types.StringValue("https://fake.vydev.io/trains"),
+		Name:       types.StringValue("trains"),
+		Timeouts:   nullTimeouts(updatableResourceTimeouts),
+	}
+	for _, name := range names {
+		model.Scopes = append(model.Scopes, scope{Name: types.StringValue(name), Description: types.StringValue(name)})
+	}
+
+	return planOf(t, schema, &model)
+}
+
+// planOf builds a plan, or a state through its Raw value, from a resource model.
+func planOf(t *testing.T, schema fwresource.SchemaResponse, model any) tfsdk.Plan {
+	t.Helper()
+	ctx := context.Background()
+
+	plan := tfsdk.Plan{
+		Schema: schema.Schema,
+		Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("could not build plan: %v", diags)
+	}
+
+	return plan
+}
+
+func TestResourceServerResource_ModifyPlanWarnsAboutRemovedScopesInUse(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["https://fake.vydev.io/trains"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/trains",
+		Name:       "trains",
+		Scopes: []central_cognito.Scope{
+			{Name: "read", Description: "read"},
+			{Name: "write", Description: "write"},
+			{Name: "admin", Description: "admin"},
+		},
+	}
+	apis.Cognito.AppClients["other-team"] = central_cognito.AppClient{
+		Name:      "other-team",
+		Type:      "backend",
+		AccountId: "222222222222",
+		Scopes:    []string{"https://fake.vydev.io/trains/write"},
+	}
+
+	ctx := context.Background()
+	r := ResourceServerResource{client: apis.CognitoClient}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	state := resourceServerPlan(t, schema, "read", "write", "admin")
+
+	tests := []struct {
+		name        string
+		plan        tfsdk.Plan
+		wantWarning bool
+	}{
+		{name: "scope in use is removed", plan: resourceServerPlan(t, schema, "read"), wantWarning: true},
+		{name: "unused scope is removed", plan: resourceServerPlan(t, schema, "read", "write"), wantWarning: false},
+		{name: "resource server is destroyed", plan: tfsdk.Plan{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)}, wantWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+				Plan:  tt.plan,
+			}
+			resp := fwresource.ModifyPlanResponse{Plan: tt.plan}
+
+			r.ModifyPlan(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			warnings := resp.Diagnostics.Warnings()
+			if !tt.wantWarning {
+				if len(warnings) != 0 {
+					t.Errorf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), "other-team (backend, account 222222222222)") {
+				t.Errorf("expected a warning about other-team, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestResourceServerResource_ModifyPlanRecordsPlannedScopes(t *testing.T) {
+	apis := startFakeAPIs(t)
+
+	ctx := context.Background()
+	r := ResourceServerResource{client: apis.CognitoClient, plannedScopes: &plannedScopes{}}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	plan := resourceServerPlan(t, schema, "read", "write")
+	req := fwresource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)},
+		Plan:  plan,
+	}
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+
+	r.ModifyPlan(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	for _, planned := range []string{"https://fake.vydev.io/trains/read", "https://fake.vydev.io/trains/write"} {
+		if !r.plannedScopes.contains(planned) {
+			t.Errorf("expected %s to be planned", planned)
+		}
+	}
+	if r.plannedScopes.contains("https://fake.vydev.io/trains/admin") {
+		t.Errorf("expected only the scopes in the plan to be planned")
+	}
+}
+
+func TestResourceServerResource_CreateSetsStateFromResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     []scope
+		wantScopes []scope
+	}{
+		{
+			name:       "normalized by the server",
+			scopes:     []scope{{Name: types.StringValue("read"), Description: types.StringValue("  Read trains  ")}},
+			wantScopes: []scope{{Name: types.StringValue("read"), Description: types.StringValue("Read trains")}},
+		},
+		{
+			name:       "without scopes",
+			scopes:     nil,
+			wantScopes: nil,
+		},
+		{
+			name:       "with an empty list of scopes",
+			scopes:     []scope{},
+			wantScopes: []scope{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			apis := startFakeAPIs(t)
+
+			r := ResourceServerResource{client: apis.CognitoClient}
+
+			var schema fwresource.SchemaResponse
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+			plan := planOf(t, schema, &ResourceServerResourceModel{
+				Id:         types.StringUnknown(),
+				Identifier: types.StringValue("https://fake.vydev.io/trains"),
+				Name:       types.StringValue(" trains "),
+				Scopes:     tt.scopes,
+				Timeouts:   nullTimeouts(updatableResourceTimeouts),
+			})
+			request := fwresource.CreateRequest{
+				Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+				Plan:   plan,
+			}
+			response := fwresource.CreateResponse{
+				State: tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)},
+			}
+
+			r.Create(ctx, request, &response)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", response.Diagnostics)
+			}
+
+			var state ResourceServerResourceModel
+			response.State.Get(ctx, &state)
+
+			if state.Name.ValueString() != "trains" {
+				t.Errorf("expected the name stored by the server, got %s", state.Name)
+			}
+			if !reflect.DeepEqual(state.Scopes, tt.wantScopes) {
+				t.Errorf("expected scopes %#v in state, got %#v", tt.wantScopes, state.Scopes)
+			}
+		})
+	}
+}
+
+func TestResourceServerResource_UpdateSetsStateFromResponse(t *testing.T) {
+	ctx := context.Background()
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["https://fake.vydev.io/trains"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/trains",
+		Name:       "trains",
+		Scopes: []central_cognito.Scope{
+			{Name: "read", Description: "read"},
+			{Name: "admin", Description: "Added by another module"},
+		},
+	}
+
+	r := ResourceServerResource{client: apis.CognitoClient}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	prior := resourceServerPlan(t, schema, "read")
+	plan := planOf(t, schema, &ResourceServerResourceModel{
+		Id:         types.StringValue("https://fake.vydev.io/trains"),
+		Identifier: types.StringValue("https://fake.vydev.io/trains"),
+		Name:       types.StringValue(" Trains "),
+		Scopes: []scope{
+			{Name: types.StringValue("read"), Description: types.StringValue("read")},
+			{Name: types.StringValue("write"), Description: types.StringValue(" Write trains ")},
+		},
+		Timeouts: nullTimeouts(updatableResourceTimeouts),
+	})
+
+	request := fwresource.UpdateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+	}
+	response := fwresource.UpdateResponse{
+		State: tfsdk.State{Schema: prior.Schema, Raw: prior.Raw},
+	}
+
+	r.Update(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", response.Diagnostics)
+	}
+
+	var state ResourceServerResourceModel
+	response.State.Get(ctx, &state)
+
+	if state.Name.ValueString() != "Trains" {
+		t.Errorf("expected the name stored by the server, got %s", state.Name)
+	}
+
+	want := []scope{
+		{Name: types.StringValue("read"), Description: types.StringValue("read")},
+		{Name: types.StringValue("write"), Description: types.StringValue("Write trains")},
+	}
+	if !reflect.DeepEqual(state.Scopes, want) {
+		t.Errorf("expected scopes %v in state, without the ones managed elsewhere, got %v", want, state.Scopes)
+	}
+}
+
+func TestResourceServerResource_ImportKeepsScopesManagedElsewhere(t *testing.T) {
+	ctx := context.Background()
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["https://fake.vydev.io/trains"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/trains",
+		Name:       "trains",
+		Scopes: []central_cognito.Scope{
+			{Name: "read", Description: "read"},
+			{Name: "write", Description: "Managed by vy_resource_server_scope"},
+		},
+	}
+
+	r := ResourceServerResource{client: apis.CognitoClient}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	empty := tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)}
+	imported := fwresource.ImportStateResponse{State: empty}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "https://fake.vydev.io/trains"}, &imported)
+	if imported.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", imported.Diagnostics)
+	}
+
+	var importedState ResourceServerResourceModel
+	imported.State.Get(ctx, &importedState)
+	if importedState.Scopes != nil {
+		t.Fatalf("expected no scopes to be imported, got %v", importedState.Scopes)
+	}
+
+	// The next apply declares only the scope that the resource server manages.
+	plan := resourceServerPlan(t, schema, "read")
+	request := fwresource.UpdateRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+		State:  imported.State,
+	}
+	response := fwresource.UpdateResponse{State: imported.State}
+
+	r.Update(ctx, request, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", response.Diagnostics)
+	}
+
+	want := []central_cognito.Scope{
+		{Name: "read", Description: "read"},
+		{Name: "write", Description: "Managed by vy_resource_server_scope"},
+	}
+	if got := apis.Cognito.ResourceServers["https://fake.vydev.io/trains"].Scopes; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the scope managed elsewhere to be kept, %v, got %v", want, got)
+	}
+
+	var state ResourceServerResourceModel
+	response.State.Get(ctx, &state)
+	if wantState := []scope{{Name: types.StringValue("read"), Description: types.StringValue("read")}}; !reflect.DeepEqual(state.Scopes, wantState) {
+		t.Errorf("expected the declared scope to be taken over, %v, got %v", wantState, state.Scopes)
+	}
+}
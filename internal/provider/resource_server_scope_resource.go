@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ resource.ResourceWithImportState = &ResourceServerScopeResource{}
+var _ resource.ResourceWithModifyPlan = &ResourceServerScopeResource{}
+
+func NewResourceServerScopeResource() resource.Resource {
+	return &ResourceServerScopeResource{}
+}
+
+type ResourceServerScopeResource struct {
+	client *central_cognito.Client
+	// plannedScopes tells app clients planned later that this scope is created.
+	plannedScopes *plannedScopes
+}
+
+type ResourceServerScopeResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	ResourceServer types.String `tfsdk:"resource_server"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r ResourceServerScopeResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource_server_scope"
+}
+
+func (r ResourceServerScopeResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "A single scope of a resource server. " +
+			"Use this to add scopes to a resource server from several modules, " +
+			"without them having to agree on the full set of scopes.\n\n" +
+			"Don't declare the same scope in both this resource and the `scopes` of `vy_resource_server`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The full name of the scope, as `identifier/name`. This is what app clients request.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_server": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource server to add the scope to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					resourceServerIdentifierValidator{},
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "A name for this scope",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					scopeNameValidator{},
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of what this scope is for",
+				Required:            true,
+				Validators: []validator.String{
+					notBlankValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, updatableResourceTimeouts),
+		},
+	}
+}
+
+func (r *ResourceServerScopeResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+	r.plannedScopes = configuration.plannedScopes
+}
+
+// ModifyPlan records the scope as planned, so app clients that are planned later can use it.
+func (r ResourceServerScopeResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	// Nothing is created when the scope is destroyed.
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+
+	var resourceServer types.String
+	var name types.String
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("resource_server"), &resourceServer)...)
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if response.Diagnostics.HasError() || resourceServer.IsUnknown() || name.IsUnknown() {
+		return
+	}
+
+	r.plannedScopes.add(resourceServer.ValueString() + "/" + name.ValueString())
+}
+
+func (r ResourceServerScopeResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data ResourceServerScopeResourceModel
+
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	err := r.client.CreateResourceServerScope(ctx, data.ResourceServer.ValueString(), central_cognito.Scope{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Could not create resource server scope",
+			fmt.Sprintf("Scope %s could not be added to resource server %s: %s", data.Name.ValueString(), data.ResourceServer.ValueString(), err.Error()),
+		)
+		response.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	data.Id = types.StringValue(data.ResourceServer.ValueString() + "/" + data.Name.ValueString())
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerScopeResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data ResourceServerScopeResourceModel
+
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var remote central_cognito.Scope
+	err := r.client.ReadResourceServerScope(ctx, data.ResourceServer.ValueString(), data.Name.ValueString(), &remote)
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "Resource server scope no longer exists in remote, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Unable to read resource server scope",
+			"Can't read resource server scope "+data.Id.String()+" from remote: "+err.Error(),
+		)
+		response.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.Id = types.StringValue(data.ResourceServer.ValueString() + "/" + remote.Name)
+	data.Name = types.StringValue(remote.Name)
+	data.Description = types.StringValue(remote.Description)
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerScopeResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data ResourceServerScopeResourceModel
+
+	diags := request.Plan.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	err := r.client.UpdateResourceServerScope(ctx, data.ResourceServer.ValueString(), central_cognito.Scope{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Unable to update resource server scope",
+			"Can't update resource server scope "+data.Id.String()+": "+err.Error(),
+		)
+		response.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	diags = response.State.Set(ctx, &data)
+	response.Diagnostics.Append(diags...)
+}
+
+func (r ResourceServerScopeResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data ResourceServerScopeResourceModel
+
+	diags := request.State.Get(ctx, &data)
+	response.Diagnostics.Append(diags...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteResourceServerScope(ctx, data.ResourceServer.ValueString(), data.Name.ValueString())
+	// The scope is gone with its resource server if that was deleted first.
+	if err != nil && !api_errors.IsNotFound(err) {
+		diags = diag.Diagnostics{}
+		diags.AddError(
+			"Unable to delete resource server scope",
+			"Can't delete resource server scope "+data.Id.String()+": "+err.Error(),
+		)
+		response.Diagnostics.Append(diags...)
+
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+// ImportState imports a scope by its full name, `identifier/name`.
+// The identifier may contain slashes itself, so the name is what follows the last one.
+func (r ResourceServerScopeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	separator := strings.LastIndex(req.ID, "/")
+	if separator <= 0 || separator == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unable to import resource server scope",
+			fmt.Sprintf("Expected an ID like `identifier/name`, got %q", req.ID),
+		)
+		return
+	}
+
+	identifier, name := req.ID[:separator], req.ID[separator+1:]
+
+	var remote central_cognito.Scope
+	err := r.client.ReadResourceServerScope(ctx, identifier, name, &remote)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import resource server scope",
+			fmt.Sprintf("The scope %s could not be read: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.State.Set(ctx, &ResourceServerScopeResourceModel{
+		Id:             types.StringValue(req.ID),
+		ResourceServer: types.StringValue(identifier),
+		Name:           types.StringValue(remote.Name),
+		Description:    types.StringValue(remote.Description),
+		Timeouts:       nullTimeouts(updatableResourceTimeouts),
+	})
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+const testFakeResourceServerScope_Create = testFake_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "https://fake.vydev.io/shared"
+	name = "shared"
+
+	scopes = [
+		{
+			name = "read"
+			description = "Allows for reading of stuff"
+		}
+	]
+}
+
+resource "vy_resource_server_scope" "write" {
+	resource_server = vy_resource_server.test.identifier
+	name = "write"
+	description = "Allows for writing of stuff"
+}
+`
+
+const testFakeResourceServerScope_Update = testFake_ProviderConfig + `
+resource "vy_resource_server" "test" {
+	identifier = "https://fake.vydev.io/shared"
+	name = "shared"
+
+	scopes = [
+		{
+			name = "read"
+			description = "Allows for reading of everything"
+		}
+	]
+}
+
+resource "vy_resource_server_scope" "write" {
+	resource_server = vy_resource_server.test.identifier
+	name = "write"
+	description = "Allows for writing of everything"
+}
+`
+
+// testCheckRemoteScopes checks that the resource server in the fake has exactly the given scopes.
+func testCheckRemoteScopes(apis *fakeAPIs, identifier string, expected map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		var server central_cognito.ResourceServer
+		if err := apis.CognitoClient.ReadResourceServer(context.Background(), identifier, &server); err != nil {
+			return err
+		}
+
+		if len(server.Scopes) != len(expected) {
+			return fmt.Errorf("expected %d scopes, got %v", len(expected), server.Scopes)
+		}
+		for _, scope := range server.Scopes {
+			if expected[scope.Name] != scope.Description {
+				return fmt.Errorf("expected scope %s to have description %q, got %q", scope.Name, expected[scope.Name], scope.Description)
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestFakeResourceServerScope_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	expected_resource_name := "vy_resource_server_scope.write"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				// Both resources own a scope each, and neither plans to remove the other's.
+				Config: testFakeResourceServerScope_Create,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "id", "https://fake.vydev.io/shared/write"),
+					resource.TestCheckResourceAttr("vy_resource_server.test", "scopes.#", "1"),
+					testCheckRemoteScopes(apis, "https://fake.vydev.io/shared", map[string]string{
+						"read":  "Allows for reading of stuff",
+						"write": "Allows for writing of stuff",
+					}),
+				),
+			},
+			{
+				Config: testFakeResourceServerScope_Update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "description", "Allows for writing of everything"),
+					testCheckRemoteScopes(apis, "https://fake.vydev.io/shared", map[string]string{
+						"read":  "Allows for reading of everything",
+						"write": "Allows for writing of everything",
+					}),
+				),
+			},
+			{
+				ResourceName:      expected_resource_name,
+				ImportState:       true,
+				ImportStateId:     "https://fake.vydev.io/shared/write",
+				ImportStateVerify: true,
+			},
+			{
+				// The resource server is imported again, without the scope that vy_resource_server_scope manages.
+				ResourceName:       "vy_resource_server.test",
+				ImportState:        true,
+				ImportStateId:      "https://fake.vydev.io/shared",
+				ImportStatePersist: true,
+			},
+			{
+				// Applying after the import takes over the declared scope, and keeps the other one.
+				Config: testFakeResourceServerScope_Update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vy_resource_server.test", "scopes.#", "1"),
+					testCheckRemoteScopes(apis, "https://fake.vydev.io/shared", map[string]string{
+						"read":  "Allows for reading of everything",
+						"write": "Allows for writing of everything",
+					}),
+				),
+			},
+			{
+				// The scope is deleted outside of Terraform, so it should be planned to be created again.
+				PreConfig: func() {
+					if err := apis.CognitoClient.DeleteResourceServerScope(context.Background(), "https://fake.vydev.io/shared", "write"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testFakeResourceServerScope_Update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceServerScopeResource_ModifyPlanRecordsPlannedScope(t *testing.T) {
+	ctx := context.Background()
+	r := ResourceServerScopeResource{plannedScopes: &plannedScopes{}}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	plan := planOf(t, schema, &ResourceServerScopeResourceModel{
+		Id:             types.StringUnknown(),
+		ResourceServer: types.StringValue("https://fake.vydev.io/trains"),
+		Name:           types.StringValue("read"),
+		Description:    types.StringValue("read"),
+		Timeouts:       nullTimeouts(updatableResourceTimeouts),
+	})
+	req := fwresource.ModifyPlanRequest{
+		State: tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), nil)},
+		Plan:  plan,
+	}
+	resp := fwresource.ModifyPlanResponse{Plan: plan}
+
+	r.ModifyPlan(ctx, req, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !r.plannedScopes.contains("https://fake.vydev.io/trains/read") {
+		t.Errorf("expected the scope to be planned")
+	}
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &ResourceServersDataSource{}
+
+func NewResourceServersDataSource() datasource.DataSource {
+	return &ResourceServersDataSource{}
+}
+
+type ResourceServersDataSource struct {
+	client *central_cognito.Client
+}
+
+type ResourceServersDataSourceModel struct {
+	Id              types.String         `tfsdk:"id"`
+	NamePrefix      types.String         `tfsdk:"name_prefix"`
+	Scope           types.String         `tfsdk:"scope"`
+	ResourceServers []resourceServerItem `tfsdk:"resource_servers"`
+}
+
+type resourceServerItem struct {
+	Identifier types.String `tfsdk:"identifier"`
+	Name       types.String `tfsdk:"name"`
+	Scopes     []scope      `tfsdk:"scopes"`
+}
+
+func (r ResourceServersDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_resource_servers"
+}
+
+func (r ResourceServersDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "List the resource servers registered in the shared Cognito, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list resource servers with a name that starts with this prefix",
+				Optional:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Only list resource servers that have a scope with this name",
+				Optional:            true,
+			},
+			"resource_servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The resource servers, sorted by their identifier",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"identifier": schema.StringAttribute{
+							MarkdownDescription: "The identity of the resource server",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the resource server",
+							Computed:            true,
+						},
+						"scopes": schema.SetNestedAttribute{
+							MarkdownDescription: "Scopes of the resource server",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "The name of the scope",
+										Computed:            true,
+									},
+									"description": schema.StringAttribute{
+										MarkdownDescription: "A description of what the scope is for",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ResourceServersDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+}
+
+func (r ResourceServersDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state ResourceServersDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	servers, err := r.client.ListResourceServers(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to list resource servers",
+			fmt.Sprintf("Can't list resource servers from remote: %s", err.Error()),
+		)
+		return
+	}
+
+	slices.SortFunc(servers, func(a, b central_cognito.ResourceServer) int {
+		return strings.Compare(a.Identifier, b.Identifier)
+	})
+
+	state.Id = types.StringValue("resource-servers")
+	state.ResourceServers = []resourceServerItem{}
+
+	for _, server := range servers {
+		if !strings.HasPrefix(server.Name, state.NamePrefix.ValueString()) {
+			continue
+		}
+		if !state.Scope.IsNull() && !hasScope(server, state.Scope.ValueString()) {
+			continue
+		}
+
+		item := resourceServerItem{
+			Identifier: types.StringValue(server.Identifier),
+			Name:       types.StringValue(server.Name),
+			Scopes:     []scope{},
+		}
+		for _, domainScope := range server.Scopes {
+			item.Scopes = append(item.Scopes, scope{
+				Name:        types.StringValue(domainScope.Name),
+				Description: types.StringValue(domainScope.Description),
+			})
+		}
+
+		state.ResourceServers = append(state.ResourceServers, item)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+func hasScope(server central_cognito.ResourceServer, name string) bool {
+	for _, domainScope := range server.Scopes {
+		if domainScope.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeResourceServersDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.PageSize = 1
+
+	for _, server := range []central_cognito.ResourceServer{
+		{Identifier: "trains.fake.io", Name: "trains", Scopes: []central_cognito.Scope{{Name: "read", Description: "Read trains"}}},
+		{Identifier: "tracks.fake.io", Name: "tracks", Scopes: []central_cognito.Scope{{Name: "admin", Description: "Manage tracks"}}},
+		{Identifier: "buses.fake.io", Name: "buses", Scopes: []central_cognito.Scope{{Name: "read", Description: "Read buses"}}},
+	} {
+		apis.Cognito.ResourceServers[server.Identifier] = server
+	}
+	expected_resource_name := "data.vy_resource_servers.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `data "vy_resource_servers" "this" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "resource_servers.#", "3"),
+					resource.TestCheckResourceAttr(expected_resource_name, "resource_servers.0.identifier", "buses.fake.io"),
+				),
+			},
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_resource_servers" "this" {
+	name_prefix = "tr"
+	scope       = "read"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "resource_servers.#", "1"),
+					resource.TestCheckResourceAttr(expected_resource_name, "resource_servers.0.name", "trains"),
+					resource.TestCheckResourceAttr(expected_resource_name, "resource_servers.0.scopes.#", "1"),
+				),
+			},
+		},
+	})
+}
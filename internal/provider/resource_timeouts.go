@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// updatableResourceTimeouts are the timeouts of resources that are updated in place.
+var updatableResourceTimeouts = timeouts.Opts{
+	Create: true,
+	Read:   true,
+	Update: true,
+	Delete: true,
+}
+
+// replacedResourceTimeouts are the timeouts of resources that are replaced instead of updated,
+// so they have no update timeout.
+var replacedResourceTimeouts = timeouts.Opts{
+	Create: true,
+	Read:   true,
+	Delete: true,
+}
+
+// resourceTimeoutsBlock is the `timeouts` block shared by all our resources, with the given operations.
+// Operations that are not configured use defaultResourceTimeout.
+func resourceTimeoutsBlock(ctx context.Context, opts timeouts.Opts) schema.Block {
+	return timeouts.Block(ctx, opts)
+}
+
+// nullTimeouts is used when there is no config to take the timeouts from, like when importing.
+// The operations must match the `timeouts` block of the resource.
+func nullTimeouts(opts timeouts.Opts) timeouts.Value {
+	attributeTypes := map[string]attr.Type{}
+	for name, enabled := range map[string]bool{
+		"create": opts.Create,
+		"read":   opts.Read,
+		"update": opts.Update,
+		"delete": opts.Delete,
+	} {
+		if enabled {
+			attributeTypes[name] = types.StringType
+		}
+	}
+
+	return timeouts.Value{
+		Object: types.ObjectNull(attributeTypes),
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestNullTimeouts_MatchesTimeoutsBlock(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts timeouts.Opts
+	}{
+		{name: "updatable", opts: updatableResourceTimeouts},
+		{name: "replaced", opts: replacedResourceTimeouts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := resourceTimeoutsBlock(ctx, tt.opts)
+			value := nullTimeouts(tt.opts)
+
+			if !block.Type().Equal(value.Type(ctx)) {
+				t.Errorf("null timeouts have type %s, want the type of the block, %s", value.Type(ctx), block.Type())
+			}
+		})
+	}
+}
+
+func TestResourceTimeoutsBlock_ReplacedResourcesHaveNoUpdateTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	for _, r := range []interface {
+		Schema(context.Context, fwresource.SchemaRequest, *fwresource.SchemaResponse)
+	}{
+		&DeploymentAccountResource{},
+		&EnvironmentAccountResource{},
+		&ScopeGrantResource{},
+	} {
+		var response fwresource.SchemaResponse
+		r.Schema(ctx, fwresource.SchemaRequest{}, &response)
+
+		attributes := response.Schema.Blocks["timeouts"].GetNestedObject().GetAttributes()
+		if _, ok := attributes["update"]; ok {
+			t.Errorf("%T has an update timeout, but is never updated in place", r)
+		}
+	}
+}
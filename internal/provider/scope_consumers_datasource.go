@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &ScopeConsumersDataSource{}
+
+func NewScopeConsumersDataSource() datasource.DataSource {
+	return &ScopeConsumersDataSource{}
+}
+
+type ScopeConsumersDataSource struct {
+	client *central_cognito.Client
+}
+
+type ScopeConsumersDataSourceModel struct {
+	Id             types.String        `tfsdk:"id"`
+	ResourceServer types.String        `tfsdk:"resource_server"`
+	Scopes         []scopeConsumerItem `tfsdk:"scopes"`
+}
+
+type scopeConsumerItem struct {
+	Name      types.String     `tfsdk:"name"`
+	Consumers []consumerClient `tfsdk:"consumers"`
+}
+
+type consumerClient struct {
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	AccountId types.String `tfsdk:"account_id"`
+}
+
+func (r ScopeConsumersDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_scope_consumers"
+}
+
+func (r ScopeConsumersDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "List the app clients that hold each scope of a resource server, across all teams. " +
+			"Use this to find out who depends on a scope before removing or renaming it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_server": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource server",
+				Required:            true,
+			},
+			"scopes": schema.ListNestedAttribute{
+				MarkdownDescription: "Every scope of the resource server, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the scope",
+							Computed:            true,
+						},
+						"consumers": schema.ListNestedAttribute{
+							MarkdownDescription: "The app clients holding the scope, sorted by name. Empty when nobody does.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "The name of the app client",
+										Computed:            true,
+									},
+									"type": schema.StringAttribute{
+										MarkdownDescription: "The type of the app client, either `frontend` or `backend`",
+										Computed:            true,
+									},
+									"account_id": schema.StringAttribute{
+										MarkdownDescription: "The AWS account that owns the app client",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ScopeConsumersDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+}
+
+func (r ScopeConsumersDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state ScopeConsumersDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	identifier := state.ResourceServer.ValueString()
+
+	var server central_cognito.ResourceServer
+	err := r.client.ReadResourceServer(ctx, identifier, &server)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read resource server",
+			fmt.Sprintf("Can't read resource server %s from remote: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	consumers, err := r.client.ListScopeConsumers(ctx, identifier)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to list scope consumers",
+			fmt.Sprintf("Can't list the consumers of resource server %s from remote: %s", identifier, err.Error()),
+		)
+		return
+	}
+
+	byScope := consumersByScope(consumers)
+
+	state.Id = types.StringValue(identifier)
+	state.Scopes = []scopeConsumerItem{}
+
+	names := []string{}
+	for _, domainScope := range server.Scopes {
+		names = append(names, domainScope.Name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		item := scopeConsumerItem{
+			Name:      types.StringValue(name),
+			Consumers: []consumerClient{},
+		}
+		for _, consumer := range byScope[name] {
+			item.Consumers = append(item.Consumers, consumerClient{
+				Name:      types.StringValue(consumer.AppClientName),
+				Type:      types.StringValue(consumer.AppClientType),
+				AccountId: types.StringValue(consumer.AccountId),
+			})
+		}
+
+		state.Scopes = append(state.Scopes, item)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
+
+// consumersByScope groups the consumers by the name of their scope, each group sorted by app client name.
+func consumersByScope(consumers []central_cognito.ScopeConsumer) map[string][]central_cognito.ScopeConsumer {
+	byScope := map[string][]central_cognito.ScopeConsumer{}
+	for _, consumer := range consumers {
+		byScope[consumer.Scope] = append(byScope[consumer.Scope], consumer)
+	}
+
+	for _, group := range byScope {
+		slices.SortFunc(group, func(a, b central_cognito.ScopeConsumer) int {
+			return strings.Compare(a.AppClientName, b.AppClientName)
+		})
+	}
+
+	return byScope
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+func TestFakeScopeConsumersDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.ResourceServers["trains.fake.io"] = central_cognito.ResourceServer{
+		Identifier: "trains.fake.io",
+		Name:       "trains",
+		Scopes: []central_cognito.Scope{
+			{Name: "write", Description: "Write trains"},
+			{Name: "read", Description: "Read trains"},
+		},
+	}
+	apis.Cognito.AppClients["planner"] = central_cognito.AppClient{
+		Name: "planner", Type: "backend", AccountId: "222222222222", Scopes: []string{"trains.fake.io/read"},
+	}
+	apis.Cognito.AppClients["dashboard"] = central_cognito.AppClient{
+		Name: "dashboard", Type: "frontend", AccountId: fakeCallerAccountId, Scopes: []string{"trains.fake.io/read"},
+	}
+	expected_resource_name := "data.vy_scope_consumers.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_scope_consumers" "this" {
+	resource_server = "trains.fake.io"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.0.name", "read"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.0.consumers.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.0.consumers.0.name", "dashboard"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.0.consumers.1.account_id", "222222222222"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.1.name", "write"),
+					resource.TestCheckResourceAttr(expected_resource_name, "scopes.1.consumers.#", "0"),
+				),
+			},
+		},
+	})
+}
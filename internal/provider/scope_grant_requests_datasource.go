@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ datasource.DataSourceWithConfigure = &ScopeGrantRequestsDataSource{}
+
+func NewScopeGrantRequestsDataSource() datasource.DataSource {
+	return &ScopeGrantRequestsDataSource{}
+}
+
+type ScopeGrantRequestsDataSource struct {
+	client *central_cognito.Client
+}
+
+type ScopeGrantRequestsDataSourceModel struct {
+	Id             types.String            `tfsdk:"id"`
+	ResourceServer types.String            `tfsdk:"resource_server"`
+	Requests       []scopeGrantRequestItem `tfsdk:"requests"`
+}
+
+type scopeGrantRequestItem struct {
+	Id            types.String `tfsdk:"id"`
+	Scope         types.String `tfsdk:"scope"`
+	AppClientName types.String `tfsdk:"app_client_name"`
+	RequestedAt   types.String `tfsdk:"requested_at"`
+}
+
+func (r ScopeGrantRequestsDataSource) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_scope_grant_requests"
+}
+
+func (r ScopeGrantRequestsDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "List the pending requests from app clients for scopes of your resource servers. " +
+			"A request is no longer pending once a `vy_scope_grant` covers it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"resource_server": schema.StringAttribute{
+				MarkdownDescription: "Only list requests for scopes of the resource server with this identifier",
+				Optional:            true,
+			},
+			"requests": schema.ListNestedAttribute{
+				MarkdownDescription: "The pending requests, sorted by scope and app client name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the request",
+							Computed:            true,
+						},
+						"scope": schema.StringAttribute{
+							MarkdownDescription: "The full name of the requested scope, as `identifier/name`",
+							Computed:            true,
+						},
+						"app_client_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the app client that requested the scope",
+							Computed:            true,
+						},
+						"requested_at": schema.StringAttribute{
+							MarkdownDescription: "When the scope was first requested, in RFC 3339 format",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ScopeGrantRequestsDataSource) Configure(ctx context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+}
+
+func (r ScopeGrantRequestsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var state ScopeGrantRequestsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &state)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	requests, err := r.client.ListScopeGrantRequests(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to list scope grant requests",
+			fmt.Sprintf("Can't list scope grant requests from remote: %s", err.Error()),
+		)
+		return
+	}
+
+	slices.SortFunc(requests, func(a, b central_cognito.ScopeGrantRequest) int {
+		if a.Scope != b.Scope {
+			return strings.Compare(a.Scope, b.Scope)
+		}
+		return strings.Compare(a.AppClientName, b.AppClientName)
+	})
+
+	state.Id = types.StringValue("scope-grant-requests")
+	state.Requests = []scopeGrantRequestItem{}
+
+	for _, grantRequest := range requests {
+		if !state.ResourceServer.IsNull() && !strings.HasPrefix(grantRequest.Scope, state.ResourceServer.ValueString()+"/") {
+			continue
+		}
+
+		state.Requests = append(state.Requests, scopeGrantRequestItem{
+			Id:            types.StringValue(grantRequest.Id),
+			Scope:         types.StringValue(grantRequest.Scope),
+			AppClientName: types.StringValue(grantRequest.AppClientName),
+			RequestedAt:   types.StringValue(grantRequest.RequestedAt),
+		})
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+}
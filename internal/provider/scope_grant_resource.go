@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+var _ resource.ResourceWithImportState = &ScopeGrantResource{}
+var _ resource.ResourceWithConfigValidators = &ScopeGrantResource{}
+
+func NewScopeGrantResource() resource.Resource {
+	return &ScopeGrantResource{}
+}
+
+type ScopeGrantResource struct {
+	client *central_cognito.Client
+}
+
+type ScopeGrantResourceModel struct {
+	Id                   types.String `tfsdk:"id"`
+	Scope                types.String `tfsdk:"scope"`
+	AppClientName        types.String `tfsdk:"app_client_name"`
+	AppClientNamePattern types.String `tfsdk:"app_client_name_pattern"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r ScopeGrantResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_scope_grant"
+}
+
+func (r ScopeGrantResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Allows app clients of other teams to request a scope of your resource server. " +
+			"App clients that request a scope they have not been granted are rejected, " +
+			"and show up in `vy_scope_grant_requests` until a grant covers them.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "The full name of the scope to grant, as `identifier/name`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					scopeValidator{},
+				},
+			},
+			"app_client_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the app client to grant the scope to. " +
+					"Exactly one of `app_client_name` and `app_client_name_pattern` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_client_name_pattern": schema.StringAttribute{
+				MarkdownDescription: "A glob matching the names of the app clients to grant the scope to, like `*.infrademo.vydev.io`. " +
+					"Exactly one of `app_client_name` and `app_client_name_pattern` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					globValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx, replacedResourceTimeouts),
+		},
+	}
+}
+
+func (r *ScopeGrantResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	configuration, ok := request.ProviderData.(*VyProviderConfiguration)
+
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *VyProviderConfiguration, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = configuration.CognitoClient
+}
+
+// ConfigValidators checks that the grant is either for a single app client or for a pattern.
+func (r ScopeGrantResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		appClientOrPatternValidator{},
+	}
+}
+
+func scopeGrantToState(domain central_cognito.ScopeGrant, state *ScopeGrantResourceModel) {
+	state.Id = types.StringValue(domain.Id)
+	state.Scope = types.StringValue(domain.Scope)
+	state.AppClientName = types.StringPointerValue(domain.AppClientName)
+	state.AppClientNamePattern = types.StringPointerValue(domain.AppClientNamePattern)
+}
+
+func (r ScopeGrantResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data ScopeGrantResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	created, err := r.client.CreateScopeGrant(ctx, central_cognito.ScopeGrant{
+		Scope:                data.Scope.ValueString(),
+		AppClientName:        data.AppClientName.ValueStringPointer(),
+		AppClientNamePattern: data.AppClientNamePattern.ValueStringPointer(),
+	})
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Could not create scope grant",
+			fmt.Sprintf("Scope %s could not be granted: %s", data.Scope.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	scopeGrantToState(*created, &data)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (r ScopeGrantResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data ScopeGrantResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var grant central_cognito.ScopeGrant
+	err := r.client.ReadScopeGrant(ctx, data.Id.ValueString(), &grant)
+	if api_errors.IsNotFound(err) {
+		tflog.Warn(ctx, "Scope grant no longer exists in remote, removing it from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		response.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		response.Diagnostics.AddError(
+			"Unable to read scope grant",
+			"Can't read scope grant "+data.Id.String()+" from remote: "+err.Error(),
+		)
+		return
+	}
+
+	scopeGrantToState(grant, &data)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// Update only changes the timeouts, as every other attribute replaces the grant.
+func (r ScopeGrantResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data ScopeGrantResourceModel
+
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (r ScopeGrantResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data ScopeGrantResourceModel
+
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteScopeGrant(ctx, data.Id.ValueString())
+	if err != nil && !api_errors.IsNotFound(err) {
+		response.Diagnostics.AddError(
+			"Unable to delete scope grant",
+			"Can't delete scope grant "+data.Id.String()+": "+err.Error(),
+		)
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (r ScopeGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var grant central_cognito.ScopeGrant
+
+	err := r.client.ReadScopeGrant(ctx, req.ID, &grant)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to import scope grant",
+			fmt.Sprintf("The scope grant %s could not be read: %s", req.ID, err),
+		)
+		return
+	}
+
+	data := ScopeGrantResourceModel{Timeouts: nullTimeouts(replacedResourceTimeouts)}
+	scopeGrantToState(grant, &data)
+
+	resp.State.Set(ctx, &data)
+}
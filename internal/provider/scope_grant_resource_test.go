@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+const testFakeScopeGrant_AppClient = testFake_ProviderConfig + `
+resource "vy_app_client" "other_team" {
+	name = "client.other-team.vydev.io"
+	type = "backend"
+	scopes = ["https://fake.vydev.io/trains/read"]
+}
+`
+
+const testFakeScopeGrant_Grant = testFake_ProviderConfig + `
+resource "vy_scope_grant" "other_team" {
+	scope                   = "https://fake.vydev.io/trains/read"
+	app_client_name_pattern = "*.other-team.vydev.io"
+}
+`
+
+func TestFakeScopeGrant_Lifecycle(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.RequireScopeGrants = true
+	apis.Cognito.ResourceServers["https://fake.vydev.io/trains"] = central_cognito.ResourceServer{
+		Identifier: "https://fake.vydev.io/trains",
+		Name:       "trains",
+		Scopes:     []central_cognito.Scope{{Name: "read", Description: "Read trains"}},
+	}
+	expected_resource_name := "vy_scope_grant.other_team"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				// The scope has not been granted yet, so the app client is rejected.
+				Config:      testFakeScopeGrant_AppClient,
+				ExpectError: regexp.MustCompile(`has not been granted`),
+			},
+			{
+				Config: testFakeScopeGrant_Grant + `data "vy_scope_grant_requests" "this" {
+	resource_server = "https://fake.vydev.io/trains"
+	depends_on      = [vy_scope_grant.other_team]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(expected_resource_name, "id"),
+					resource.TestCheckResourceAttr("data.vy_scope_grant_requests.this", "requests.#", "0"),
+				),
+			},
+			{
+				ResourceName:      expected_resource_name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testFakeScopeGrant_Grant + `
+resource "vy_app_client" "other_team" {
+	name = "client.other-team.vydev.io"
+	type = "backend"
+	scopes = ["https://fake.vydev.io/trains/read"]
+}
+`,
+				Check: resource.TestCheckResourceAttr("vy_app_client.other_team", "scopes.#", "1"),
+			},
+			{
+				// The grant is revoked outside of Terraform, so it should be planned to be created again.
+				PreConfig: func() {
+					for id := range apis.Cognito.ScopeGrants {
+						if err := apis.CognitoClient.DeleteScopeGrant(context.Background(), id); err != nil {
+							t.Fatal(err)
+						}
+					}
+				},
+				Config:             testFakeScopeGrant_Grant,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestFakeScopeGrantRequestsDataSource(t *testing.T) {
+	apis := startFakeAPIs(t)
+	apis.Cognito.ScopeGrantRequests = map[string]central_cognito.ScopeGrantRequest{
+		"b": {Id: "b", Scope: "trains.fake.io/read", AppClientName: "b-client", RequestedAt: "2026-01-02T00:00:00Z"},
+		"a": {Id: "a", Scope: "trains.fake.io/read", AppClientName: "a-client", RequestedAt: "2026-01-01T00:00:00Z"},
+		"c": {Id: "c", Scope: "buses.fake.io/read", AppClientName: "c-client", RequestedAt: "2026-01-03T00:00:00Z"},
+	}
+	expected_resource_name := "data.vy_scope_grant_requests.this"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testFakePreCheck(t) },
+		ProtoV6ProviderFactories: apis.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testFake_ProviderConfig + `
+data "vy_scope_grant_requests" "this" {
+	resource_server = "trains.fake.io"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(expected_resource_name, "requests.#", "2"),
+					resource.TestCheckResourceAttr(expected_resource_name, "requests.0.app_client_name", "a-client"),
+					resource.TestCheckResourceAttr(expected_resource_name, "requests.0.requested_at", "2026-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func TestScopeGrantResource_ConfigValidators(t *testing.T) {
+	ctx := context.Background()
+
+	r := ScopeGrantResource{}
+
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	tests := []struct {
+		name                 string
+		appClientName        types.String
+		appClientNamePattern types.String
+		wantError            bool
+	}{
+		{name: "app client", appClientName: types.StringValue("my-app"), appClientNamePattern: types.StringNull()},
+		{name: "pattern", appClientName: types.StringNull(), appClientNamePattern: types.StringValue("my-*")},
+		{name: "both", appClientName: types.StringValue("my-app"), appClientNamePattern: types.StringValue("my-*"), wantError: true},
+		{name: "neither", appClientName: types.StringNull(), appClientNamePattern: types.StringNull(), wantError: true},
+		{name: "unknown until applied", appClientName: types.StringUnknown(), appClientNamePattern: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := planOf(t, schema, &ScopeGrantResourceModel{
+				Id:                   types.StringUnknown(),
+				Scope:                types.StringValue("https://fake.vydev.io/demo/read"),
+				AppClientName:        tt.appClientName,
+				AppClientNamePattern: tt.appClientNamePattern,
+				Timeouts:             nullTimeouts(replacedResourceTimeouts),
+			})
+
+			request := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}
+			var response fwresource.ValidateConfigResponse
+
+			for _, configValidator := range r.ConfigValidators(ctx) {
+				configValidator.ValidateResource(ctx, request, &response)
+			}
+
+			if response.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("expected error to be %v, got: %v", tt.wantError, response.Diagnostics)
+			}
+		})
+	}
+}
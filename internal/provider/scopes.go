@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+// plannedScopes are the scopes that resource servers and scopes in the configuration create, as `identifier/scope`.
+//
+// It is shared by all resources of the provider while planning. Terraform plans a resource after the resources
+// it references or depends on, so app clients see the scopes that are created before them in the same apply.
+type plannedScopes struct {
+	mu     sync.Mutex
+	scopes map[string]bool
+}
+
+// add records a scope as planned. A nil plannedScopes records nothing.
+func (p *plannedScopes) add(scope string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.scopes == nil {
+		p.scopes = map[string]bool{}
+	}
+	p.scopes[scope] = true
+}
+
+// contains tells if a scope is planned.
+func (p *plannedScopes) contains(scope string) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.scopes[scope]
+}
+
+// maxScopeSuggestions is how many of the nearest scopes are suggested for an unknown scope.
+const maxScopeSuggestions = 3
+
+// cognitoAdminScope lets users manage their own attributes in the user pool.
+// App clients can request it, but user pools leave it out of their scopes_supported.
+const cognitoAdminScope = "aws.cognito.signin.user.admin"
+
+// availableScopes lists every scope an app client can request, as `identifier/scope`,
+// along with the standard scopes that the user pool supports.
+func availableScopes(servers []central_cognito.ResourceServer, scopesSupported []string) []string {
+	scopes := append(slices.Clone(scopesSupported), cognitoAdminScope)
+	for _, server := range servers {
+		for _, scope := range server.Scopes {
+			scopes = append(scopes, server.Identifier+"/"+scope.Name)
+		}
+	}
+
+	return scopes
+}
+
+// resourceServerOf finds the resource server a scope belongs to, by the identifier the scope starts with.
+func resourceServerOf(scope string, servers []central_cognito.ResourceServer) (central_cognito.ResourceServer, bool) {
+	for _, server := range servers {
+		if strings.HasPrefix(scope, server.Identifier+"/") {
+			return server, true
+		}
+	}
+
+	return central_cognito.ResourceServer{}, false
+}
+
+// nearestScopes returns the available scopes that are closest to the given one, closest first.
+// Scopes that differ in more than half of their characters are not considered close.
+func nearestScopes(scope string, available []string) []string {
+	type candidate struct {
+		scope    string
+		distance int
+	}
+
+	candidates := []candidate{}
+	for _, other := range available {
+		distance := levenshtein(scope, other)
+		if distance*2 <= max(len(scope), len(other)) {
+			candidates = append(candidates, candidate{scope: other, distance: distance})
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.distance != b.distance {
+			return a.distance - b.distance
+		}
+		return strings.Compare(a.scope, b.scope)
+	})
+
+	nearest := []string{}
+	for _, c := range candidates[:min(len(candidates), maxScopeSuggestions)] {
+		nearest = append(nearest, c.scope)
+	}
+
+	return nearest
+}
+
+// levenshtein is the number of single character edits needed to turn a into b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
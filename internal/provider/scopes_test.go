@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNearestScopes(t *testing.T) {
+	available := []string{
+		"openid",
+		"email",
+		"https://trains.vydev.io/read",
+		"https://trains.vydev.io/write",
+		"https://tracks.vydev.io/read",
+	}
+
+	tests := []struct {
+		name  string
+		scope string
+		want  []string
+	}{
+		{name: "typo in the scope", scope: "https://trains.vydev.io/raed", want: []string{"https://trains.vydev.io/read", "https://tracks.vydev.io/read", "https://trains.vydev.io/write"}},
+		{name: "typo in the identifier", scope: "https://trian.vydev.io/write", want: []string{"https://trains.vydev.io/write", "https://trains.vydev.io/read", "https://tracks.vydev.io/read"}},
+		{name: "standard scope", scope: "opnid", want: []string{"openid"}},
+		{name: "nothing close", scope: "something/else", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearestScopes(tt.scope, available)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("nearestScopes(%q) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "read", b: "", want: 4},
+		{a: "read", b: "read", want: 0},
+		{a: "read", b: "raed", want: 2},
+		{a: "read", b: "reads", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
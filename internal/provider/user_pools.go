@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/nsbno/terraform-provider-vy/internal/central_cognito"
+)
+
+// userPool is the shared Cognito user pool of an environment.
+type userPool struct {
+	UserPoolId string
+	Region     string
+	AuthUrl    string
+	Issuer     string
+}
+
+// knownUserPools are the user pools of our environments.
+// They are only read from the delegated Cognito for other environments.
+var knownUserPools = map[string]userPool{
+	"prod": {
+		UserPoolId: "eu-west-1_e6o46c1oE",
+		Region:     "eu-west-1",
+		AuthUrl:    "https://auth.cognito.vydev.io",
+		Issuer:     "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_e6o46c1oE",
+	},
+	"stage": {
+		UserPoolId: "eu-west-1_AUYQ679zW",
+		Region:     "eu-west-1",
+		AuthUrl:    "https://auth.stage.cognito.vydev.io",
+		Issuer:     "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_AUYQ679zW",
+	},
+	"test": {
+		UserPoolId: "eu-west-1_Z53b9AbeT",
+		Region:     "eu-west-1",
+		AuthUrl:    "https://auth.test.cognito.vydev.io",
+		Issuer:     "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_Z53b9AbeT",
+	},
+	"dev": {
+		UserPoolId: "eu-west-1_0AvVv5Wyk",
+		Region:     "eu-west-1",
+		AuthUrl:    "https://auth.dev.cognito.vydev.io",
+		Issuer:     "https://cognito-idp.eu-west-1.amazonaws.com/eu-west-1_0AvVv5Wyk",
+	},
+}
+
+// defaultScopesSupported are the OpenID Connect scopes that Cognito user pools support.
+// They are only used when the OpenID configuration of the user pool can't be read.
+var defaultScopesSupported = []string{
+	"openid",
+	"email",
+	"phone",
+	"profile",
+}
+
+// cognitoInfo describes the user pool by the URLs that Cognito uses for every user pool.
+func (p userPool) cognitoInfo() central_cognito.CognitoInfo {
+	return central_cognito.CognitoInfo{
+		UserPoolId:       p.UserPoolId,
+		Region:           p.Region,
+		AuthUrl:          p.AuthUrl,
+		Issuer:           p.Issuer,
+		JwksUrl:          p.Issuer + "/.well-known/jwks.json",
+		OpenIdUrl:        p.Issuer + "/.well-known/openid-configuration",
+		TokenEndpoint:    p.AuthUrl + "/oauth2/token",
+		UserinfoEndpoint: p.AuthUrl + "/oauth2/userInfo",
+		LogoutEndpoint:   p.AuthUrl + "/logout",
+		ScopesSupported:  defaultScopesSupported,
+	}
+}
+
+// readCognitoInfo describes the user pool of the environment.
+//
+// Known user pools are described by their public OpenID configuration, which needs no AWS credentials.
+// If it can't be read, the URLs that Cognito uses for every user pool are used instead.
+// Other environments are read from the discovery endpoint of the delegated Cognito.
+func readCognitoInfo(ctx context.Context, client *central_cognito.Client, environment string, pools map[string]userPool) (central_cognito.CognitoInfo, error) {
+	pool, known := pools[environment]
+	if !known {
+		var info central_cognito.CognitoInfo
+		err := client.ReadCognitoInfo(ctx, &info)
+		return info, err
+	}
+
+	info := pool.cognitoInfo()
+
+	var config central_cognito.OpenIdConfiguration
+	err := client.ReadOpenIdConfiguration(ctx, info.OpenIdUrl, &config)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to read the OpenID configuration of the user pool, using the default URLs of Cognito", map[string]interface{}{
+			"environment": environment,
+			"error":       err.Error(),
+		})
+		return info, nil
+	}
+
+	info.Issuer = config.Issuer
+	info.JwksUrl = config.JwksUri
+	info.TokenEndpoint = config.TokenEndpoint
+	info.UserinfoEndpoint = config.UserinfoEndpoint
+	if config.EndSessionEndpoint != "" {
+		info.LogoutEndpoint = config.EndSessionEndpoint
+	}
+	if authUrl, found := strings.CutSuffix(config.AuthorizationEndpoint, "/oauth2/authorize"); found {
+		info.AuthUrl = authUrl
+	}
+	if len(config.ScopesSupported) > 0 {
+		info.ScopesSupported = config.ScopesSupported
+	}
+
+	return info, nil
+}
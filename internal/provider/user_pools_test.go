@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+)
+
+func TestReadCognitoInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("known user pool", func(t *testing.T) {
+		apis := startFakeAPIs(t)
+		apis.Cognito.Info.TokenEndpoint = "https://auth.example.com/oauth2/token"
+
+		info, err := readCognitoInfo(ctx, apis.CognitoClient, "test", apis.local.UserPools)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(info, *apis.Cognito.Info) {
+			t.Errorf("expected the OpenID configuration of the user pool, %+v, got %+v", *apis.Cognito.Info, info)
+		}
+	})
+
+	t.Run("known user pool without OpenID configuration", func(t *testing.T) {
+		apis := startFakeAPIs(t)
+		apis.Cognito.Info = nil
+
+		info, err := readCognitoInfo(ctx, apis.CognitoClient, "test", apis.local.UserPools)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := apis.local.UserPools["test"].cognitoInfo()
+		if !reflect.DeepEqual(info, want) {
+			t.Errorf("expected the default URLs of the user pool, %+v, got %+v", want, info)
+		}
+	})
+
+	t.Run("other environment", func(t *testing.T) {
+		apis := startFakeAPIs(t)
+
+		info, err := readCognitoInfo(ctx, apis.CognitoClient, "other", apis.local.UserPools)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(info, *apis.Cognito.Info) {
+			t.Errorf("expected the discovery document, %+v, got %+v", *apis.Cognito.Info, info)
+		}
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		apis := startFakeAPIs(t)
+		apis.Cognito.Info = nil
+
+		_, err := readCognitoInfo(ctx, apis.CognitoClient, "other", apis.local.UserPools)
+		if !api_errors.IsNotFound(err) {
+			t.Fatalf("expected a not found error, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,613 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	globpath "path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxCognitoNameLength is the longest identifier, name or description Cognito accepts for resource servers and scopes.
+const maxCognitoNameLength = 256
+
+// isLocalhost reports whether the host is the machine itself, which only exists while developing.
+func isLocalhost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// urlProblem explains why the URL can't be used as a callback or logout URL, or is empty when it can.
+// Custom schemes, like `myapp://callback`, are allowed for mobile apps. Plain HTTP is only allowed for localhost.
+func urlProblem(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Sprintf("'%s' is not a valid URL: %s.", raw, err)
+	}
+
+	switch {
+	case parsed.Scheme == "":
+		return fmt.Sprintf("'%s' is not an absolute URL. It must start with a scheme, like 'https://'.", raw)
+	case parsed.Fragment != "" || strings.Contains(raw, "#"):
+		return fmt.Sprintf("'%s' has a fragment, which Cognito does not allow.", raw)
+	case (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Hostname() == "":
+		return fmt.Sprintf("'%s' has no host.", raw)
+	case parsed.Scheme == "http" && !isLocalhost(parsed.Hostname()):
+		return fmt.Sprintf("'%s' must use HTTPS. Plain HTTP is only allowed for localhost.", raw)
+	}
+
+	return ""
+}
+
+var _ validator.List = urlsValidator{}
+
+// urlsValidator checks that each URL in a list is valid for Cognito, and that none are repeated.
+type urlsValidator struct{}
+
+func (t urlsValidator) Description(ctx context.Context) string {
+	return "each URL must be absolute, unique and use HTTPS unless it is for localhost"
+}
+
+func (t urlsValidator) MarkdownDescription(ctx context.Context) string {
+	return "each URL must be absolute, unique and use `https` unless it is for `localhost`"
+}
+
+func (t urlsValidator) ValidateList(ctx context.Context, request validator.ListRequest, response *validator.ListResponse) {
+	var list = request.ConfigValue
+
+	if list.IsUnknown() || list.IsNull() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+
+		if problem := urlProblem(value.ValueString()); problem != "" {
+			response.Diagnostics.AddAttributeError(request.Path.AtListIndex(i), "Invalid URL", problem)
+			continue
+		}
+
+		if seen[value.ValueString()] {
+			response.Diagnostics.AddAttributeError(
+				request.Path.AtListIndex(i),
+				"Duplicate URL",
+				fmt.Sprintf("'%s' is listed more than once.", value.ValueString()),
+			)
+		}
+		seen[value.ValueString()] = true
+	}
+}
+
+var _ validator.String = urlValidator{}
+
+// urlValidator is the urlsValidator for a single URL.
+type urlValidator struct{}
+
+func (t urlValidator) Description(ctx context.Context) string {
+	return "value must be an absolute URL that uses HTTPS unless it is for localhost"
+}
+
+func (t urlValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an absolute URL that uses `https` unless it is for `localhost`"
+}
+
+func (t urlValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if problem := urlProblem(str.ValueString()); problem != "" {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid URL", problem)
+	}
+}
+
+var _ validator.String = frontendOrBackendValidator{}
+
+type frontendOrBackendValidator struct{}
+
+func (t frontendOrBackendValidator) Description(ctx context.Context) string {
+	return "type must be either 'frontend' or 'backend'"
+}
+
+func (t frontendOrBackendValidator) MarkdownDescription(ctx context.Context) string {
+	return "type must be either `frontend` or `backend`"
+}
+
+func (t frontendOrBackendValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if str.ValueString() != "frontend" && str.ValueString() != "backend" {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid app client type",
+			fmt.Sprintf("The app client must either be 'frontend' or 'backend'. Got: '%s'.", str.ValueString()),
+		)
+
+		return
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+type durationValidator struct{}
+
+func (t durationValidator) Description(ctx context.Context) string {
+	return "value must be a duration, like '24h' or '90m'"
+}
+
+func (t durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a duration, like `24h` or `90m`"
+}
+
+func (t durationValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	duration, err := time.ParseDuration(str.ValueString())
+	if err != nil || duration < 0 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid duration",
+			fmt.Sprintf("Expected a positive duration, like '24h' or '90m'. Got: '%s'.", str.ValueString()),
+		)
+
+		return
+	}
+}
+
+var _ validator.Int64 = atLeastOneValidator{}
+
+type atLeastOneValidator struct{}
+
+func (t atLeastOneValidator) Description(ctx context.Context) string {
+	return "value must be at least 1"
+}
+
+func (t atLeastOneValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be at least `1`"
+}
+
+func (t atLeastOneValidator) ValidateInt64(ctx context.Context, request validator.Int64Request, response *validator.Int64Response) {
+	var value = request.ConfigValue
+
+	if value.IsUnknown() || value.IsNull() {
+		return
+	}
+
+	if value.ValueInt64() < 1 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid value",
+			fmt.Sprintf("The value must be at least 1. Got: %d.", value.ValueInt64()),
+		)
+
+		return
+	}
+}
+
+var _ validator.Set = oauthFlowsValidator{}
+
+type oauthFlowsValidator struct{}
+
+func (t oauthFlowsValidator) Description(ctx context.Context) string {
+	return "flows must be 'code', 'implicit' or 'client_credentials'"
+}
+
+func (t oauthFlowsValidator) MarkdownDescription(ctx context.Context) string {
+	return "flows must be `code`, `implicit` or `client_credentials`"
+}
+
+func (t oauthFlowsValidator) ValidateSet(ctx context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	var set = request.ConfigValue
+
+	if set.IsUnknown() || set.IsNull() {
+		return
+	}
+
+	for _, element := range set.Elements() {
+		flow, ok := element.(types.String)
+		if !ok || flow.IsUnknown() || flow.IsNull() {
+			continue
+		}
+
+		switch flow.ValueString() {
+		case "code", "implicit", "client_credentials":
+		default:
+			response.Diagnostics.AddAttributeError(
+				request.Path,
+				"Invalid OAuth flow",
+				fmt.Sprintf("The OAuth flows must be 'code', 'implicit' or 'client_credentials'. Got: '%s'.", flow.ValueString()),
+			)
+		}
+	}
+}
+
+var _ resource.ConfigValidator = frontendUrlsValidator{}
+
+// frontendUrlsValidator rejects URLs on backend clients, which never redirect users anywhere.
+type frontendUrlsValidator struct{}
+
+func (t frontendUrlsValidator) Description(ctx context.Context) string {
+	return "callback_urls, logout_urls and default_redirect_uri can only be set when type is 'frontend'"
+}
+
+func (t frontendUrlsValidator) MarkdownDescription(ctx context.Context) string {
+	return "`callback_urls`, `logout_urls` and `default_redirect_uri` can only be set when `type` is `frontend`"
+}
+
+func (t frontendUrlsValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var clientType types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("type"), &clientType)...)
+	if response.Diagnostics.HasError() || clientType.ValueString() != "backend" {
+		return
+	}
+
+	var callbackUrls, logoutUrls types.List
+	var redirectUri types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("callback_urls"), &callbackUrls)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("logout_urls"), &logoutUrls)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("default_redirect_uri"), &redirectUri)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	for _, set := range []struct {
+		attribute string
+		value     attr.Value
+	}{
+		{"callback_urls", callbackUrls},
+		{"logout_urls", logoutUrls},
+		{"default_redirect_uri", redirectUri},
+	} {
+		if set.value.IsNull() {
+			continue
+		}
+
+		response.Diagnostics.AddAttributeError(
+			path.Root(set.attribute),
+			"URLs on a backend app client",
+			fmt.Sprintf("Backend app clients don't sign in users, so `%s` can only be set when `type` is `frontend`.", set.attribute),
+		)
+	}
+}
+
+var _ resource.ConfigValidator = defaultRedirectUriValidator{}
+
+// defaultRedirectUriValidator checks that the default redirect URI is one of the callback URLs, as Cognito requires.
+type defaultRedirectUriValidator struct{}
+
+func (t defaultRedirectUriValidator) Description(ctx context.Context) string {
+	return "default_redirect_uri must be one of callback_urls"
+}
+
+func (t defaultRedirectUriValidator) MarkdownDescription(ctx context.Context) string {
+	return "`default_redirect_uri` must be one of `callback_urls`"
+}
+
+func (t defaultRedirectUriValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var redirectUri types.String
+	var callbackUrls types.List
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("default_redirect_uri"), &redirectUri)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("callback_urls"), &callbackUrls)...)
+	if response.Diagnostics.HasError() || redirectUri.IsNull() || redirectUri.IsUnknown() || callbackUrls.IsUnknown() {
+		return
+	}
+
+	for _, element := range callbackUrls.Elements() {
+		if element.IsUnknown() || element.Equal(redirectUri) {
+			return
+		}
+	}
+
+	response.Diagnostics.AddAttributeError(
+		path.Root("default_redirect_uri"),
+		"Invalid default redirect URI",
+		fmt.Sprintf("The default redirect URI must be one of `callback_urls`. Got: '%s'.", redirectUri.ValueString()),
+	)
+}
+
+var _ resource.ConfigValidator = localhostUrlsValidator{}
+
+// localhostUrlsValidator rejects localhost URLs in prod, where they would let anyone running a local server receive tokens.
+type localhostUrlsValidator struct {
+	// environment is the `environment` of the provider. Nothing is checked while it is unknown.
+	environment string
+}
+
+func (t localhostUrlsValidator) Description(ctx context.Context) string {
+	return "localhost URLs are not allowed in prod. URLs that are unknown until the apply are not checked, and nothing is checked while the environment of the provider is unknown"
+}
+
+func (t localhostUrlsValidator) MarkdownDescription(ctx context.Context) string {
+	return "`localhost` URLs are not allowed in `prod`. URLs that are unknown until the apply are not checked, and nothing is checked while the `environment` of the provider is unknown"
+}
+
+func (t localhostUrlsValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	if t.environment != "prod" {
+		return
+	}
+
+	for _, attribute := range []string{"callback_urls", "logout_urls"} {
+		var urls types.List
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root(attribute), &urls)...)
+		if urls.IsNull() || urls.IsUnknown() {
+			continue
+		}
+
+		for i, element := range urls.Elements() {
+			value, ok := element.(types.String)
+			if ok && !value.IsUnknown() && !value.IsNull() {
+				t.validateUrl(path.Root(attribute).AtListIndex(i), value.ValueString(), response)
+			}
+		}
+	}
+
+	var redirectUri types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("default_redirect_uri"), &redirectUri)...)
+	if !redirectUri.IsNull() && !redirectUri.IsUnknown() {
+		t.validateUrl(path.Root("default_redirect_uri"), redirectUri.ValueString(), response)
+	}
+}
+
+func (t localhostUrlsValidator) validateUrl(at path.Path, raw string, response *resource.ValidateConfigResponse) {
+	parsed, err := url.Parse(raw)
+	if err != nil || !isLocalhost(parsed.Hostname()) {
+		return
+	}
+
+	response.Diagnostics.AddAttributeError(
+		at,
+		"Localhost URL in prod",
+		fmt.Sprintf("'%s' points at localhost, which is only allowed outside of prod.", raw),
+	)
+}
+
+var _ resource.ConfigValidator = appClientOrPatternValidator{}
+
+// appClientOrPatternValidator checks that a scope grant is either for a single app client or for a pattern.
+type appClientOrPatternValidator struct{}
+
+func (t appClientOrPatternValidator) Description(ctx context.Context) string {
+	return "exactly one of app_client_name and app_client_name_pattern must be set"
+}
+
+func (t appClientOrPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return "exactly one of `app_client_name` and `app_client_name_pattern` must be set"
+}
+
+func (t appClientOrPatternValidator) ValidateResource(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var appClientName, appClientNamePattern types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("app_client_name"), &appClientName)...)
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("app_client_name_pattern"), &appClientNamePattern)...)
+	if response.Diagnostics.HasError() || appClientName.IsUnknown() || appClientNamePattern.IsUnknown() {
+		return
+	}
+
+	if appClientName.IsNull() == appClientNamePattern.IsNull() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("app_client_name"),
+			"Invalid scope grant",
+			"Exactly one of `app_client_name` and `app_client_name_pattern` must be set.",
+		)
+	}
+}
+
+var _ validator.String = resourceServerIdentifierValidator{}
+
+// resourceServerIdentifierValidator checks the characters Cognito allows in an identifier.
+// Scopes are requested as `identifier/name`, so the identifier can't end with a slash.
+type resourceServerIdentifierValidator struct{}
+
+func (t resourceServerIdentifierValidator) Description(ctx context.Context) string {
+	return "identifier must be printable ASCII without spaces, quotes or backslashes, and must not end with '/'"
+}
+
+func (t resourceServerIdentifierValidator) MarkdownDescription(ctx context.Context) string {
+	return "identifier must be printable ASCII without spaces, quotes or backslashes, and must not end with `/`"
+}
+
+func (t resourceServerIdentifierValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	identifier := str.ValueString()
+
+	var problem string
+	switch {
+	case identifier == "":
+		problem = "The identifier can't be empty."
+	case len(identifier) > maxCognitoNameLength:
+		problem = fmt.Sprintf("The identifier can be at most %d characters. Got %d.", maxCognitoNameLength, len(identifier))
+	case strings.HasSuffix(identifier, "/"):
+		problem = fmt.Sprintf("The identifier can't end with '/', as scopes are named '<identifier>/<scope>'. Got: '%s'.", identifier)
+	default:
+		if c, found := invalidCharacter(identifier, `"\`); found {
+			problem = fmt.Sprintf("The identifier can't contain %q. Got: '%s'.", c, identifier)
+		}
+	}
+
+	if problem != "" {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid resource server identifier", problem)
+	}
+}
+
+var _ validator.String = scopeNameValidator{}
+
+// scopeNameValidator checks the characters Cognito allows in the name of a scope.
+type scopeNameValidator struct{}
+
+func (t scopeNameValidator) Description(ctx context.Context) string {
+	return "scope name must be printable ASCII without spaces, quotes, slashes or backslashes"
+}
+
+func (t scopeNameValidator) MarkdownDescription(ctx context.Context) string {
+	return "scope name must be printable ASCII without spaces, quotes, slashes or backslashes"
+}
+
+func (t scopeNameValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	name := str.ValueString()
+
+	var problem string
+	switch {
+	case name == "":
+		problem = "The scope name can't be empty."
+	case len(name) > maxCognitoNameLength:
+		problem = fmt.Sprintf("The scope name can be at most %d characters. Got %d.", maxCognitoNameLength, len(name))
+	default:
+		if c, found := invalidCharacter(name, `"/\`); found {
+			problem = fmt.Sprintf("The scope name can't contain %q. Got: '%s'.", c, name)
+		}
+	}
+
+	if problem != "" {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid scope name", problem)
+	}
+}
+
+var _ validator.String = scopeValidator{}
+
+// scopeValidator checks the full name of a scope, `identifier/name`, with the validators of each part.
+// The identifier may contain slashes itself, so the name is what follows the last one.
+type scopeValidator struct{}
+
+func (t scopeValidator) Description(ctx context.Context) string {
+	return "scope must be the full name of a scope, as 'identifier/name'"
+}
+
+func (t scopeValidator) MarkdownDescription(ctx context.Context) string {
+	return "scope must be the full name of a scope, as `identifier/name`"
+}
+
+func (t scopeValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	scope := str.ValueString()
+
+	separator := strings.LastIndex(scope, "/")
+	if separator <= 0 || separator == len(scope)-1 {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid scope",
+			fmt.Sprintf("The scope must be the full name of a scope, as 'identifier/name'. Got: '%s'.", scope),
+		)
+		return
+	}
+
+	identifier := validator.StringRequest{Path: request.Path, ConfigValue: types.StringValue(scope[:separator])}
+	resourceServerIdentifierValidator{}.ValidateString(ctx, identifier, response)
+
+	name := validator.StringRequest{Path: request.Path, ConfigValue: types.StringValue(scope[separator+1:])}
+	scopeNameValidator{}.ValidateString(ctx, name, response)
+}
+
+var _ validator.String = globValidator{}
+
+// globValidator checks that the value is a valid glob, like `*.infrademo.vydev.io`.
+type globValidator struct{}
+
+func (t globValidator) Description(ctx context.Context) string {
+	return "value must be a valid glob"
+}
+
+func (t globValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a valid glob"
+}
+
+func (t globValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if _, err := globpath.Match(str.ValueString(), ""); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid glob",
+			fmt.Sprintf("%q is not a valid glob: %s", str.ValueString(), err),
+		)
+	}
+}
+
+// invalidCharacter returns the first character that is whitespace, not printable ASCII, or one of the forbidden ones.
+func invalidCharacter(value string, forbidden string) (rune, bool) {
+	for _, c := range value {
+		if c <= ' ' || c > '~' || strings.ContainsRune(forbidden, c) {
+			return c, true
+		}
+	}
+
+	return 0, false
+}
+
+var _ validator.String = notBlankValidator{}
+
+// notBlankValidator rejects strings that are empty or only whitespace, and those longer than Cognito accepts.
+type notBlankValidator struct{}
+
+func (t notBlankValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must not be blank, and at most %d characters", maxCognitoNameLength)
+}
+
+func (t notBlankValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must not be blank, and at most `%d` characters", maxCognitoNameLength)
+}
+
+func (t notBlankValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	var str = request.ConfigValue
+
+	if str.IsUnknown() || str.IsNull() {
+		return
+	}
+
+	if strings.TrimSpace(str.ValueString()) == "" {
+		response.Diagnostics.AddAttributeError(request.Path, "Invalid value", "The value can't be empty.")
+		return
+	}
+
+	if len(str.ValueString()) > maxCognitoNameLength {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid value",
+			fmt.Sprintf("The value can be at most %d characters. Got %d.", maxCognitoNameLength, len(str.ValueString())),
+		)
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUrlProblem(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantValid bool
+	}{
+		{url: "https://petstore.infrademo.vydev.io/auth/callback", wantValid: true},
+		{url: "http://localhost:3000/auth/callback", wantValid: true},
+		{url: "http://127.0.0.1:3000/logout", wantValid: true},
+		{url: "no.vy.app://callback", wantValid: true},
+		{url: "http://petstore.infrademo.vydev.io/auth/callback", wantValid: false},
+		{url: "petstore.infrademo.vydev.io/auth/callback", wantValid: false},
+		{url: "https://petstore.infrademo.vydev.io/#/callback", wantValid: false},
+		{url: "https:///callback", wantValid: false},
+		{url: "https://petstore.infrademo.vydev.io/%zz", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			problem := urlProblem(tt.url)
+			if tt.wantValid && problem != "" {
+				t.Errorf("expected %q to be valid, got: %s", tt.url, problem)
+			}
+			if !tt.wantValid && problem == "" {
+				t.Errorf("expected %q to be invalid", tt.url)
+			}
+		})
+	}
+}
+
+func TestUrlsValidator_RejectsDuplicates(t *testing.T) {
+	request := validator.ListRequest{
+		Path: path.Root("callback_urls"),
+		ConfigValue: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("https://example.com/callback"),
+			types.StringValue("https://example.com/callback"),
+		}),
+	}
+	var response validator.ListResponse
+
+	urlsValidator{}.ValidateList(context.Background(), request, &response)
+
+	if response.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error, got %v", response.Diagnostics)
+	}
+}
+
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     string
+		wantValid bool
+	}{
+		{name: "hostname identifier", validator: resourceServerIdentifierValidator{}, value: "service.vydev.io", wantValid: true},
+		{name: "url identifier", validator: resourceServerIdentifierValidator{}, value: "https://fake.vydev.io/demo", wantValid: true},
+		{name: "identifier with space", validator: resourceServerIdentifierValidator{}, value: "my service", wantValid: false},
+		{name: "identifier with trailing slash", validator: resourceServerIdentifierValidator{}, value: "https://fake.vydev.io/", wantValid: false},
+		{name: "empty identifier", validator: resourceServerIdentifierValidator{}, value: "", wantValid: false},
+		{name: "scope name", validator: scopeNameValidator{}, value: "read:all", wantValid: true},
+		{name: "scope name with space", validator: scopeNameValidator{}, value: "read all", wantValid: false},
+		{name: "scope name with slash", validator: scopeNameValidator{}, value: "read/all", wantValid: false},
+		{name: "scope", validator: scopeValidator{}, value: "https://fake.vydev.io/demo/read", wantValid: true},
+		{name: "scope without identifier", validator: scopeValidator{}, value: "read", wantValid: false},
+		{name: "scope without name", validator: scopeValidator{}, value: "https://fake.vydev.io/demo/", wantValid: false},
+		{name: "scope with invalid identifier", validator: scopeValidator{}, value: "my service/read", wantValid: false},
+		{name: "scope with invalid name", validator: scopeValidator{}, value: "https://fake.vydev.io/demo/read\"all", wantValid: false},
+		{name: "glob", validator: globValidator{}, value: "*.infrademo.vydev.io", wantValid: true},
+		{name: "invalid glob", validator: globValidator{}, value: "[infrademo", wantValid: false},
+		{name: "description", validator: notBlankValidator{}, value: "Allows for reading of stuff", wantValid: true},
+		{name: "blank description", validator: notBlankValidator{}, value: "  ", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := validator.StringRequest{Path: path.Root("value"), ConfigValue: types.StringValue(tt.value)}
+			var response validator.StringResponse
+
+			tt.validator.ValidateString(context.Background(), request, &response)
+
+			if tt.wantValid && response.Diagnostics.HasError() {
+				t.Errorf("expected %q to be valid, got: %v", tt.value, response.Diagnostics)
+			}
+			if !tt.wantValid && !response.Diagnostics.HasError() {
+				t.Errorf("expected %q to be invalid", tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultSubsystem is used for requests from a transport without a subsystem.
+const defaultSubsystem = "http"
+
+const redacted = "***"
+
+// sensitiveHeaders are masked before headers are logged.
+// They are the ones set when signing a request.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":        true,
+	"X-Amz-Security-Token": true,
+}
+
+// sensitiveBodyFields matches string values of JSON fields that hold credentials, like `client_secret` or `access_token`.
+var sensitiveBodyFields = regexp.MustCompile(`("\w*(?:secret|_token|password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// requestIdHeaders are the headers API Gateway uses to identify a request, in order of preference.
+var requestIdHeaders = []string{"X-Amzn-Requestid", "X-Amz-Apigw-Id"}
+
+func (t Transport) logContext(ctx context.Context) (context.Context, string) {
+	subsystem := t.Subsystem
+	if subsystem == "" {
+		subsystem = defaultSubsystem
+	}
+
+	return tflog.NewSubsystem(ctx, subsystem), subsystem
+}
+
+func logRequest(ctx context.Context, subsystem string, request *http.Request, attempt int) {
+	tflog.SubsystemDebug(ctx, subsystem, "Sending request", map[string]interface{}{
+		"method":  request.Method,
+		"url":     request.URL.String(),
+		"attempt": attempt,
+	})
+
+	fields := map[string]interface{}{
+		"headers": redactHeaders(request.Header),
+	}
+	if request.GetBody != nil {
+		fields["body"] = &lazyBody{read: func() []byte {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil
+			}
+			defer body.Close()
+
+			content, _ := io.ReadAll(body)
+			return content
+		}}
+	}
+	tflog.SubsystemTrace(ctx, subsystem, "Request details", fields)
+}
+
+func logResponse(ctx context.Context, subsystem string, request *http.Request, attempt int, response *http.Response, err error, duration time.Duration) {
+	fields := map[string]interface{}{
+		"method":      request.Method,
+		"url":         request.URL.String(),
+		"attempt":     attempt,
+		"duration_ms": duration.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, subsystem, "Request failed", fields)
+		return
+	}
+
+	fields["status_code"] = response.StatusCode
+	fields["request_id"] = requestId(response.Header)
+	tflog.SubsystemDebug(ctx, subsystem, "Received response", fields)
+
+	// When it is logged, the body is read and put back for the caller.
+	// If reading fails, the caller gets the same error after the part that was read.
+	body := &lazyBody{read: func() []byte {
+		content, readErr := io.ReadAll(response.Body)
+		response.Body.Close()
+		if readErr != nil {
+			response.Body = io.NopCloser(io.MultiReader(bytes.NewReader(content), errorReader{readErr}))
+			return content
+		}
+		response.Body = io.NopCloser(bytes.NewReader(content))
+		return content
+	}}
+
+	tflog.SubsystemTrace(ctx, subsystem, "Response details", map[string]interface{}{
+		"headers": redactHeaders(response.Header),
+		"body":    body,
+	})
+}
+
+// lazyBody is a body that is only read, and redacted, when its log entry is written.
+// tflog has no way to check the level of a subsystem, but only formats the fields of entries it writes,
+// so bodies are not buffered unless TRACE is enabled.
+type lazyBody struct {
+	read func() []byte
+
+	once    sync.Once
+	content string
+}
+
+func (b *lazyBody) String() string {
+	b.once.Do(func() {
+		b.content = redactBody(b.read())
+	})
+
+	return b.content
+}
+
+func (b *lazyBody) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func requestId(header http.Header) string {
+	for _, name := range requestIdHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// redactHeaders flattens the headers for logging, masking the ones used for authentication.
+func redactHeaders(header http.Header) map[string]string {
+	flattened := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			flattened[name] = redacted
+			continue
+		}
+
+		flattened[name] = strings.Join(values, ", ")
+	}
+
+	return flattened
+}
+
+// redactBody masks credentials in a JSON body, so it can be logged.
+func redactBody(body []byte) string {
+	return sensitiveBodyFields.ReplaceAllString(string(body), `${1}"`+redacted+`"`)
+}
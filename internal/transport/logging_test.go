@@ -0,0 +1,161 @@
+package transport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"name":"my-app"}`, `{"name":"my-app"}`},
+		{`{"name":"my-app","client_secret":"s3cr3t"}`, `{"name":"my-app","client_secret":"***"}`},
+		{`{"client_secret": "with \"quotes\""}`, `{"client_secret": "***"}`},
+		{`{"access_token":"eyJ","token_type":"Bearer"}`, `{"access_token":"***","token_type":"Bearer"}`},
+		{`{"client_secret":null}`, `{"client_secret":null}`},
+		{``, ``},
+	}
+
+	for _, tt := range tests {
+		if got := redactBody([]byte(tt.body)); got != tt.want {
+			t.Errorf("redactBody(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestRedactHeaders_MasksSigningHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/...")
+	header.Set("X-Amz-Security-Token", "session-token")
+	header.Set("Content-Type", "application/json")
+
+	got := redactHeaders(header)
+
+	if got["Authorization"] != redacted || got["X-Amz-Security-Token"] != redacted {
+		t.Errorf("headers = %v, want the signing headers to be masked", got)
+	}
+	if got["Content-Type"] != "application/json" {
+		t.Errorf("Content-Type = %q, want it to be kept", got["Content-Type"])
+	}
+}
+
+func TestDo_LogsRequestAndResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-RequestId", "c6af9ac6-7b61-11e6-9a41-93e8deadbeef")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name":"my-app","client_secret":"generated-secret"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &output)
+
+	sign := func(request *http.Request) error {
+		request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/...")
+		return nil
+	}
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/app-clients", bytes.NewBufferString(`{"name":"my-app"}`))
+	response, err := Transport{HTTPClient: server.Client(), Sign: sign, Subsystem: "central_cognito"}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	if !strings.Contains(string(body), "generated-secret") {
+		t.Errorf("body = %q, want the caller to get the unredacted body", body)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received map[string]interface{}
+	for _, entry := range entries {
+		if entry["@module"] != "provider.central_cognito" {
+			t.Errorf("@module = %v, want the service subsystem", entry["@module"])
+		}
+		if entry["@message"] == "Received response" {
+			received = entry
+		}
+	}
+	if received == nil {
+		t.Fatalf("no response was logged: %v", entries)
+	}
+	if received["method"] != "POST" || received["status_code"] != float64(201) || received["attempt"] != float64(1) {
+		t.Errorf("response entry = %v, want method, status code and attempt", received)
+	}
+	if received["request_id"] != "c6af9ac6-7b61-11e6-9a41-93e8deadbeef" {
+		t.Errorf("request_id = %v, want the API Gateway request ID", received["request_id"])
+	}
+	if _, ok := received["duration_ms"]; !ok {
+		t.Errorf("response entry = %v, want a duration", received)
+	}
+
+	if strings.Contains(output.String(), "generated-secret") || strings.Contains(output.String(), "AKIDEXAMPLE") {
+		t.Errorf("logs contain secrets: %s", output.String())
+	}
+}
+
+// countingBody records whether the body was read.
+type countingBody struct {
+	io.Reader
+	reads int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	b.reads++
+	return b.Reader.Read(p)
+}
+
+func (b *countingBody) Close() error {
+	return nil
+}
+
+func TestLogResponse_OnlyReadsBodyWhenTracing(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "https://example.com/app-clients/my-app", nil)
+
+	t.Run("debug", func(t *testing.T) {
+		t.Setenv("TF_LOG_TRANSPORT_TEST", "DEBUG")
+		ctx := tfsdklog.NewRootProviderLogger(t.Context(), tfsdklog.WithLevelFromEnv("TF_LOG_TRANSPORT_TEST"))
+
+		body := &countingBody{Reader: strings.NewReader(`{"name":"my-app"}`)}
+		response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body}
+
+		logResponse(ctx, "central_cognito", request, 1, response, nil, time.Millisecond)
+
+		if body.reads != 0 || response.Body != body {
+			t.Errorf("expected the body to be left alone when TRACE is disabled, it was read %d times", body.reads)
+		}
+	})
+
+	t.Run("trace", func(t *testing.T) {
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(t.Context(), &output)
+
+		body := &countingBody{Reader: strings.NewReader(`{"name":"my-app","client_secret":"generated-secret"}`)}
+		response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body}
+
+		logResponse(ctx, "central_cognito", request, 1, response, nil, time.Millisecond)
+
+		content, _ := io.ReadAll(response.Body)
+		if string(content) != `{"name":"my-app","client_secret":"generated-secret"}` {
+			t.Errorf("body = %q, want the caller to get the whole body", content)
+		}
+		if !strings.Contains(output.String(), `{\"name\":\"my-app\",\"client_secret\":\"***\"}`) {
+			t.Errorf("expected the redacted body to be logged, got: %s", output.String())
+		}
+	})
+}
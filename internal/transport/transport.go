@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls how failed requests are retried.
+// A MaxAttempts of 1 or less disables retries.
+type RetryConfig struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    20 * time.Second,
+	}
+}
+
+// Transport sends requests to our APIs, retrying throttled and transient failures.
+type Transport struct {
+	HTTPClient *http.Client
+	// Sign is called before every attempt, so each retry is sent with a fresh signature.
+	// Leave it nil to send unsigned requests.
+	Sign  func(request *http.Request) error
+	Retry RetryConfig
+	// Timeout limits each attempt, including reading the response body. Zero means no timeout.
+	Timeout time.Duration
+	// Subsystem is the tflog subsystem requests are logged to, usually the name of the service.
+	Subsystem string
+}
+
+// Do sends the request, retrying it with exponential backoff and jitter.
+//
+// Idempotent requests are retried on network errors and on throttling or gateway errors.
+// Other requests are only retried when the API marks the failure as safe to retry,
+// either by throttling us (429) or by sending a Retry-After header.
+func (t Transport) Do(request *http.Request) (*http.Response, error) {
+	httpClient := t.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if t.Timeout > 0 {
+		withTimeout := *httpClient
+		withTimeout.Timeout = t.Timeout
+		httpClient = &withTimeout
+	}
+
+	logCtx, subsystem := t.logContext(request.Context())
+
+	for attempt := 1; ; attempt++ {
+		attemptRequest, err := cloneRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Sign != nil {
+			if err := t.Sign(attemptRequest); err != nil {
+				return nil, err
+			}
+		}
+
+		logRequest(logCtx, subsystem, attemptRequest, attempt)
+		start := time.Now()
+		response, err := httpClient.Do(attemptRequest)
+		logResponse(logCtx, subsystem, attemptRequest, attempt, response, err, time.Since(start))
+
+		if attempt >= t.Retry.MaxAttempts || !shouldRetry(request, response, err) {
+			return response, err
+		}
+
+		delay := t.Retry.backoff(attempt)
+		if response != nil {
+			if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+				delay = min(retryAfter, t.Retry.MaxDelay)
+			}
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if err := wait(request.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// cloneRequest makes a fresh copy of the request with a rewound body,
+// so it can be signed and sent again.
+func cloneRequest(request *http.Request) (*http.Request, error) {
+	clone := request.Clone(request.Context())
+
+	if request.Body != nil && request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+
+	return clone, nil
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func shouldRetry(request *http.Request, response *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+
+	// Requests without a replayable body can't be sent twice.
+	if request.Body != nil && request.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(request.Method)
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests || response.Header.Get("Retry-After") != "" {
+		return true
+	}
+
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(request.Method)
+	default:
+		return false
+	}
+}
+
+// backoff returns a random delay between zero and the exponential backoff for the given attempt.
+func (r RetryConfig) backoff(attempt int) time.Duration {
+	delay := r.BaseDelay
+	for i := 1; i < attempt && delay < r.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, r.MaxDelay)
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// parseRetryAfter parses both forms of the Retry-After header: seconds and an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func wait(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryConfig{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+// flakyServer responds with the given status codes in order, then 200 for every following request.
+func flakyServer(statusCodes ...int) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(statusCodes) {
+			w.WriteHeader(statusCodes[requests-1])
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	return server, &requests
+}
+
+func TestDo_RetriesIdempotentRequestOnTransientErrors(t *testing.T) {
+	server, requests := flakyServer(http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	response, err := Transport{HTTPClient: server.Client(), Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", response.StatusCode, http.StatusOK)
+	}
+	if *requests != 3 {
+		t.Errorf("requests = %d, want %d", *requests, 3)
+	}
+}
+
+func TestDo_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, requests := flakyServer(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	response, err := Transport{HTTPClient: server.Client(), Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want the last failed response %d", response.StatusCode, http.StatusServiceUnavailable)
+	}
+	if *requests != 3 {
+		t.Errorf("requests = %d, want %d", *requests, 3)
+	}
+}
+
+func TestDo_DoesNotRetryNonIdempotentRequestOnServerError(t *testing.T) {
+	server, requests := flakyServer(http.StatusServiceUnavailable)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString(`{}`))
+	response, err := Transport{HTTPClient: server.Client(), Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("StatusCode = %d, want %d", response.StatusCode, http.StatusServiceUnavailable)
+	}
+	if *requests != 1 {
+		t.Errorf("requests = %d, want %d", *requests, 1)
+	}
+}
+
+func TestDo_RetriesThrottledNonIdempotentRequestWithSameBody(t *testing.T) {
+	server, requests := flakyServer(http.StatusTooManyRequests)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString(`{"name":"my-app"}`))
+	response, err := Transport{HTTPClient: server.Client(), Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	if string(body) != `{"name":"my-app"}` {
+		t.Errorf("body = %q, want the original body to be sent again", body)
+	}
+	if *requests != 2 {
+		t.Errorf("requests = %d, want %d", *requests, 2)
+	}
+}
+
+func TestDo_DoesNotRetryClientErrors(t *testing.T) {
+	server, requests := flakyServer(http.StatusNotFound)
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	response, err := Transport{HTTPClient: server.Client(), Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if *requests != 1 {
+		t.Errorf("requests = %d, want %d", *requests, 1)
+	}
+}
+
+func TestDo_SignsEveryAttempt(t *testing.T) {
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("Authorization"))
+		if len(signatures) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	attempt := 0
+	sign := func(request *http.Request) error {
+		attempt++
+		request.Header.Set("Authorization", string(rune('0'+attempt)))
+		return nil
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	response, err := Transport{HTTPClient: server.Client(), Sign: sign, Retry: fastRetry}.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	if len(signatures) != 2 || signatures[0] != "1" || signatures[1] != "2" {
+		t.Errorf("signatures = %v, want a fresh signature for each attempt", signatures)
+	}
+}
+
+func TestDo_StopsWaitingWhenContextIsCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	retry := RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Minute}
+	_, err := Transport{HTTPClient: server.Client(), Retry: retry}.Do(request)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"0", 0, true},
+		{"soon", 0, false},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBackoff_StaysWithinMaxDelay(t *testing.T) {
+	retry := RetryConfig{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	for attempt := 1; attempt <= 64; attempt++ {
+		if delay := retry.backoff(attempt); delay < 0 || delay > retry.MaxDelay {
+			t.Errorf("backoff(%d) = %v, want it between 0 and %v", attempt, delay, retry.MaxDelay)
+		}
+	}
+}
@@ -1,17 +1,40 @@
 package version_handler
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"time"
 
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
 )
 
 type Client struct {
-	BaseUrl string
+	BaseUrl        string
+	HTTPClient     *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	Signer         *aws_auth.Signer
+	Retry          transport.RetryConfig
+	RequestTimeout time.Duration
+}
+
+// logSubsystem is the tflog subsystem that requests to this service are logged to.
+const logSubsystem = "version_handler"
+
+func (c Client) send(request *http.Request) (*http.Response, error) {
+	t := transport.Transport{
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Timeout:    c.RequestTimeout,
+		Subsystem:  logSubsystem,
+	}
+	if c.HTTPClient == nil {
+		t.Sign = c.Signer.Sign
+	}
+
+	return t.Do(request)
 }
 
 type Version struct {
@@ -22,17 +45,23 @@ type Version struct {
 	Version         string `json:"version"`
 }
 
-func (c Client) ReadVersion(application_name string, version *Version) error {
-	request, err := http.NewRequest(
+func (c Client) ReadVersion(ctx context.Context, application_name string, version *Version) error {
+	protocol := "https://"
+	if c.HTTPClient != nil {
+		protocol = "http://"
+	}
+
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
-		fmt.Sprintf("https://%s/versions/%s", c.BaseUrl, application_name),
+		fmt.Sprintf("%s%s/versions/%s", protocol, c.BaseUrl, application_name),
 		nil,
 	)
 	if err != nil {
 		return err
 	}
 
-	response, err := aws_auth.SignedRequest(request)
+	response, err := c.send(request)
 	if err != nil {
 		return err
 	}
@@ -40,9 +69,7 @@ func (c Client) ReadVersion(application_name string, version *Version) error {
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		return errors.New(fmt.Sprintf("could not read artifact version. %s", str))
+		return api_errors.FromResponse("could not read artifact version", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(version)
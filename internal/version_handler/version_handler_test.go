@@ -0,0 +1,106 @@
+package version_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler"
+)
+
+func TestReadVersion(t *testing.T) {
+	knownVersions := map[string]version_handler.Version{
+		"trafficinfo": {
+			ApplicationName: "trafficinfo",
+			URI:             "s3://artifacts/trafficinfo/abc123.zip",
+			Store:           "artifacts",
+			Path:            "trafficinfo/abc123.zip",
+			Version:         "abc123",
+		},
+		"infrastructure-docker": {
+			ApplicationName: "infrastructure-docker",
+			URI:             "123456789012.dkr.ecr.eu-west-1.amazonaws.com/infrastructure-docker:def456",
+			Store:           "123456789012.dkr.ecr.eu-west-1.amazonaws.com",
+			Path:            "infrastructure-docker",
+			Version:         "def456",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		applicationName string
+		want            version_handler.Version
+		wantErr         error
+	}{
+		{
+			name:            "returns the version of an S3 artifact",
+			applicationName: "trafficinfo",
+			want:            knownVersions["trafficinfo"],
+		},
+		{
+			name:            "returns the version of an ECR image",
+			applicationName: "infrastructure-docker",
+			want:            knownVersions["infrastructure-docker"],
+		},
+		{
+			name:            "returns not found for an unknown application",
+			applicationName: "does-not-exist",
+			wantErr:         api_errors.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakes.VersionHandlerAPI{Versions: knownVersions}
+			server, client := api.Start()
+			defer server.Close()
+
+			var got version_handler.Version
+			err := client.ReadVersion(context.Background(), tt.applicationName, &got)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("version = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadVersion_PropagatesServerErrorWithStatusAndMessage(t *testing.T) {
+	brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(api_errors.Payload{
+			Message:   "parameter store unreachable",
+			ErrorType: "INTERNAL_ERROR",
+		})
+	}))
+	defer brokenServer.Close()
+
+	client := &version_handler.Client{
+		BaseUrl:    strings.TrimPrefix(brokenServer.URL, "http://"),
+		HTTPClient: brokenServer.Client(),
+	}
+
+	var version version_handler.Version
+	err := client.ReadVersion(context.Background(), "trafficinfo", &version)
+	if err == nil {
+		t.Fatal("expected an error from a broken server, got nil")
+	}
+	if !strings.Contains(err.Error(), "500") {
+		t.Errorf("error = %q, want it to contain HTTP status '500'", err.Error())
+	}
+	if !strings.Contains(err.Error(), "parameter store unreachable") {
+		t.Errorf("error = %q, want it to contain the API error message", err.Error())
+	}
+}
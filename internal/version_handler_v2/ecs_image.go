@@ -1,14 +1,14 @@
 package version_handler_v2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
 
-	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 )
 
 type ECSVersion struct {
@@ -22,7 +22,7 @@ type ECSVersion struct {
 	ECRRepositoryURI     string `json:"ecr_repository_uri"`
 }
 
-func (c Client) ReadECSImage(githubRepositoryName string, ecrRepositoryName string, workingDirectory string,
+func (c Client) ReadECSImage(ctx context.Context, githubRepositoryName string, ecrRepositoryName string, workingDirectory string,
 	ecsVersion *ECSVersion) error {
 
 	protocol := "https://"
@@ -44,7 +44,8 @@ func (c Client) ReadECSImage(githubRepositoryName string, ecrRepositoryName stri
 		reqURL = reqURL + "&" + q
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
 		reqURL,
 		nil,
@@ -53,14 +54,7 @@ func (c Client) ReadECSImage(githubRepositoryName string, ecrRepositoryName stri
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		// Use HTTP client for testing
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		// Use AWS signed request for production
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 
 	if err != nil {
 		return err
@@ -69,22 +63,7 @@ func (c Client) ReadECSImage(githubRepositoryName string, ecrRepositoryName stri
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-
-		var apiErr apiErrorPayload
-		if err := json.Unmarshal(str, &apiErr); err == nil && (apiErr.Message != "" || apiErr.ErrorType != "") {
-			return fmt.Errorf(
-				"%d: %s",
-				response.StatusCode,
-				apiErr.Message,
-			)
-		}
-
-		return fmt.Errorf(
-			"%d: %s",
-			response.StatusCode,
-			strings.TrimSpace(string(str)),
-		)
+		return api_errors.FromResponse("could not read ECS image", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(ecsVersion)
@@ -1,16 +1,21 @@
-package version_handler_v2
+package version_handler_v2_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler_v2"
 )
 
 func TestReadECSImage_ReturnsVersionForMatchingRepositoryAndECRName(t *testing.T) {
-	api := &FakeVersionHandlerAPI{
-		KnownECSVersions: []ECSVersion{
+	api := &fakes.VersionHandlerV2API{
+		KnownECSVersions: []version_handler_v2.ECSVersion{
 			{
 				GitHubRepositoryName: "nsbno/my-service",
 				ECRRepositoryName:    "my-service",
@@ -22,8 +27,8 @@ func TestReadECSImage_ReturnsVersionForMatchingRepositoryAndECRName(t *testing.T
 	server, client := api.Start()
 	defer server.Close()
 
-	var version ECSVersion
-	err := client.ReadECSImage("nsbno/my-service", "my-service", "", &version)
+	var version version_handler_v2.ECSVersion
+	err := client.ReadECSImage(context.Background(), "nsbno/my-service", "my-service", "", &version)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -36,8 +41,8 @@ func TestReadECSImage_ReturnsVersionForMatchingRepositoryAndECRName(t *testing.T
 }
 
 func TestReadECSImage_DistinguishesMonorepoServicesByWorkingDirectory(t *testing.T) {
-	api := &FakeVersionHandlerAPI{
-		KnownECSVersions: []ECSVersion{
+	api := &fakes.VersionHandlerV2API{
+		KnownECSVersions: []version_handler_v2.ECSVersion{
 			{
 				GitHubRepositoryName: "nsbno/monorepo",
 				ECRRepositoryName:    "api",
@@ -55,8 +60,8 @@ func TestReadECSImage_DistinguishesMonorepoServicesByWorkingDirectory(t *testing
 	server, client := api.Start()
 	defer server.Close()
 
-	var version ECSVersion
-	err := client.ReadECSImage("nsbno/monorepo", "api", "services/worker", &version)
+	var version version_handler_v2.ECSVersion
+	err := client.ReadECSImage(context.Background(), "nsbno/monorepo", "api", "services/worker", &version)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -66,38 +71,41 @@ func TestReadECSImage_DistinguishesMonorepoServicesByWorkingDirectory(t *testing
 }
 
 func TestReadECSImage_ReturnsErrorWhenVersionDoesNotExist(t *testing.T) {
-	api := &FakeVersionHandlerAPI{}
+	api := &fakes.VersionHandlerV2API{}
 	server, client := api.Start()
 	defer server.Close()
 
-	var version ECSVersion
-	err := client.ReadECSImage("nsbno/nonexistent", "no-such-ecr", "", &version)
+	var version version_handler_v2.ECSVersion
+	err := client.ReadECSImage(context.Background(), "nsbno/nonexistent", "no-such-ecr", "", &version)
 	if err == nil {
 		t.Fatal("expected an error for a missing ECS version, got nil")
 	}
 	if !strings.Contains(err.Error(), "404") {
 		t.Errorf("error = %q, want it to contain HTTP status '404'", err.Error())
 	}
+	if !api_errors.IsNotFound(err) {
+		t.Errorf("error = %v, want it to be a not found error", err)
+	}
 }
 
 func TestReadECSImage_PropagatesServerErrorWithStatusAndMessage(t *testing.T) {
 	brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(apiErrorPayload{
+		json.NewEncoder(w).Encode(api_errors.Payload{
 			Message:   "ECR registry unreachable",
 			ErrorType: "INTERNAL_ERROR",
 		})
 	}))
 	defer brokenServer.Close()
 
-	client := &Client{
+	client := &version_handler_v2.Client{
 		BaseUrl:    strings.TrimPrefix(brokenServer.URL, "http://"),
 		HTTPClient: brokenServer.Client(),
 	}
 
-	var version ECSVersion
-	err := client.ReadECSImage("nsbno/my-service", "my-service", "", &version)
+	var version version_handler_v2.ECSVersion
+	err := client.ReadECSImage(context.Background(), "nsbno/my-service", "my-service", "", &version)
 	if err == nil {
 		t.Fatal("expected an error from a broken server, got nil")
 	}
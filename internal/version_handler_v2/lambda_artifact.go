@@ -1,14 +1,14 @@
 package version_handler_v2
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
 
-	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
 )
 
 type LambdaArtifact struct {
@@ -33,7 +33,7 @@ func normalizePath(p string) string {
 	return p
 }
 
-func (c Client) ReadLambdaArtifact(githubRepositoryName string, ecrRepositoryName string, workingDirectory string, path string,
+func (c Client) ReadLambdaArtifact(ctx context.Context, githubRepositoryName string, ecrRepositoryName string, workingDirectory string, path string,
 	lambdaArtifact *LambdaArtifact) error {
 
 	protocol := "https://"
@@ -57,7 +57,8 @@ func (c Client) ReadLambdaArtifact(githubRepositoryName string, ecrRepositoryNam
 		reqURL = reqURL + "?" + strings.Join(q, "&")
 	}
 
-	request, err := http.NewRequest(
+	request, err := http.NewRequestWithContext(
+		ctx,
 		http.MethodGet,
 		reqURL,
 		nil,
@@ -66,14 +67,7 @@ func (c Client) ReadLambdaArtifact(githubRepositoryName string, ecrRepositoryNam
 		return err
 	}
 
-	var response *http.Response
-	if c.HTTPClient != nil {
-		// Use HTTP client for testing
-		response, err = c.HTTPClient.Do(request)
-	} else {
-		// Use AWS signed request for production
-		response, err = aws_auth.SignedRequest(request)
-	}
+	response, err := c.send(request)
 
 	if err != nil {
 		return err
@@ -82,21 +76,7 @@ func (c Client) ReadLambdaArtifact(githubRepositoryName string, ecrRepositoryNam
 	defer response.Body.Close()
 
 	if response.StatusCode != 200 {
-		str, _ := io.ReadAll(response.Body)
-		var apiErr apiErrorPayload
-		if err := json.Unmarshal(str, &apiErr); err == nil && (apiErr.Message != "" || apiErr.ErrorType != "") {
-			return fmt.Errorf(
-				"%d: %s",
-				response.StatusCode,
-				apiErr.Message,
-			)
-		}
-
-		return fmt.Errorf(
-			"%d: %s",
-			response.StatusCode,
-			strings.TrimSpace(string(str)),
-		)
+		return api_errors.FromResponse("could not read Lambda artifact", response)
 	}
 
 	err = json.NewDecoder(response.Body).Decode(lambdaArtifact)
@@ -1,16 +1,21 @@
-package version_handler_v2
+package version_handler_v2_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/nsbno/terraform-provider-vy/internal/api_errors"
+	"github.com/nsbno/terraform-provider-vy/internal/fakes"
+	"github.com/nsbno/terraform-provider-vy/internal/version_handler_v2"
 )
 
 func TestReadLambdaArtifact_ReturnsArtifactForMatchingRepository(t *testing.T) {
-	api := &FakeVersionHandlerAPI{
-		KnownLambdaArtifacts: []LambdaArtifact{
+	api := &fakes.VersionHandlerV2API{
+		KnownLambdaArtifacts: []version_handler_v2.LambdaArtifact{
 			{
 				GitHubRepositoryName: "nsbno/my-service",
 				S3ObjectPath:         "artifacts/lambda.zip",
@@ -22,8 +27,8 @@ func TestReadLambdaArtifact_ReturnsArtifactForMatchingRepository(t *testing.T) {
 	server, client := api.Start()
 	defer server.Close()
 
-	var artifact LambdaArtifact
-	err := client.ReadLambdaArtifact("nsbno/my-service", "", "", "", &artifact)
+	var artifact version_handler_v2.LambdaArtifact
+	err := client.ReadLambdaArtifact(context.Background(), "nsbno/my-service", "", "", "", &artifact)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -36,8 +41,8 @@ func TestReadLambdaArtifact_ReturnsArtifactForMatchingRepository(t *testing.T) {
 }
 
 func TestReadLambdaArtifact_DistinguishesMonorepoServicesByWorkingDirectory(t *testing.T) {
-	api := &FakeVersionHandlerAPI{
-		KnownLambdaArtifacts: []LambdaArtifact{
+	api := &fakes.VersionHandlerV2API{
+		KnownLambdaArtifacts: []version_handler_v2.LambdaArtifact{
 			{
 				GitHubRepositoryName: "nsbno/monorepo",
 				WorkingDirectory:     "services/billing",
@@ -53,8 +58,8 @@ func TestReadLambdaArtifact_DistinguishesMonorepoServicesByWorkingDirectory(t *t
 	server, client := api.Start()
 	defer server.Close()
 
-	var artifact LambdaArtifact
-	err := client.ReadLambdaArtifact("nsbno/monorepo", "", "services/notifications", "", &artifact)
+	var artifact version_handler_v2.LambdaArtifact
+	err := client.ReadLambdaArtifact(context.Background(), "nsbno/monorepo", "", "services/notifications", "", &artifact)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -64,8 +69,8 @@ func TestReadLambdaArtifact_DistinguishesMonorepoServicesByWorkingDirectory(t *t
 }
 
 func TestReadLambdaArtifact_FiltersArtifactByPath(t *testing.T) {
-	api := &FakeVersionHandlerAPI{
-		KnownLambdaArtifacts: []LambdaArtifact{
+	api := &fakes.VersionHandlerV2API{
+		KnownLambdaArtifacts: []version_handler_v2.LambdaArtifact{
 			{
 				GitHubRepositoryName: "nsbno/my-service",
 				Path:                 "functions/authorizer",
@@ -76,8 +81,8 @@ func TestReadLambdaArtifact_FiltersArtifactByPath(t *testing.T) {
 	server, client := api.Start()
 	defer server.Close()
 
-	var artifact LambdaArtifact
-	err := client.ReadLambdaArtifact("nsbno/my-service", "", "", "functions/authorizer", &artifact)
+	var artifact version_handler_v2.LambdaArtifact
+	err := client.ReadLambdaArtifact(context.Background(), "nsbno/my-service", "", "", "functions/authorizer", &artifact)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -87,12 +92,12 @@ func TestReadLambdaArtifact_FiltersArtifactByPath(t *testing.T) {
 }
 
 func TestReadLambdaArtifact_ReturnsErrorWhenArtifactDoesNotExist(t *testing.T) {
-	api := &FakeVersionHandlerAPI{} // no known artifacts
+	api := &fakes.VersionHandlerV2API{} // no known artifacts
 	server, client := api.Start()
 	defer server.Close()
 
-	var artifact LambdaArtifact
-	err := client.ReadLambdaArtifact("nsbno/nonexistent", "", "", "", &artifact)
+	var artifact version_handler_v2.LambdaArtifact
+	err := client.ReadLambdaArtifact(context.Background(), "nsbno/nonexistent", "", "", "", &artifact)
 	if err == nil {
 		t.Fatal("expected an error for a missing artifact, got nil")
 	}
@@ -105,20 +110,20 @@ func TestReadLambdaArtifact_PropagatesServerErrorWithStatusAndMessage(t *testing
 	brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(apiErrorPayload{
+		json.NewEncoder(w).Encode(api_errors.Payload{
 			Message:   "version database unavailable",
 			ErrorType: "INTERNAL_ERROR",
 		})
 	}))
 	defer brokenServer.Close()
 
-	client := &Client{
+	client := &version_handler_v2.Client{
 		BaseUrl:    strings.TrimPrefix(brokenServer.URL, "http://"),
 		HTTPClient: brokenServer.Client(),
 	}
 
-	var artifact LambdaArtifact
-	err := client.ReadLambdaArtifact("nsbno/my-service", "", "", "", &artifact)
+	var artifact version_handler_v2.LambdaArtifact
+	err := client.ReadLambdaArtifact(context.Background(), "nsbno/my-service", "", "", "", &artifact)
 	if err == nil {
 		t.Fatal("expected an error from a broken server, got nil")
 	}
@@ -1,13 +1,34 @@
 package version_handler_v2
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+
+	"github.com/nsbno/terraform-provider-vy/internal/aws_auth"
+	"github.com/nsbno/terraform-provider-vy/internal/transport"
+)
 
 type Client struct {
-	BaseUrl    string
-	HTTPClient *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	BaseUrl        string
+	HTTPClient     *http.Client // Optional: if set, used instead of AWS signed requests (for testing)
+	Signer         *aws_auth.Signer
+	Retry          transport.RetryConfig
+	RequestTimeout time.Duration
 }
 
-type apiErrorPayload struct {
-	Message   string `json:"message"`
-	ErrorType string `json:"error_type"`
+// logSubsystem is the tflog subsystem that requests to this service are logged to.
+const logSubsystem = "version_handler_v2"
+
+func (c Client) send(request *http.Request) (*http.Response, error) {
+	t := transport.Transport{
+		HTTPClient: c.HTTPClient,
+		Retry:      c.Retry,
+		Timeout:    c.RequestTimeout,
+		Subsystem:  logSubsystem,
+	}
+	if c.HTTPClient == nil {
+		t.Sign = c.Signer.Sign
+	}
+
+	return t.Do(request)
 }